@@ -174,6 +174,22 @@ func Run(opts *options.Options, stopChan <-chan struct{}) error {
 		klog.Info("KubeFed will target all namespaces")
 	}
 
+	opts.Config.ClusterManager, err = utils.NewClusterManager(opts.Config.KubeConfig, opts.Config.KubeFedNamespace)
+	if err != nil {
+		klog.Fatalf("Error creating cluster manager: %v", err)
+	}
+
+	if opts.Sharding != nil {
+		opts.Config.Shards, err = leaderelection.NewKubeFedShardManager(opts)
+		if err != nil {
+			klog.Fatalf("Error creating shard manager: %v", err)
+		}
+		go opts.Config.Shards.Run(stopChan)
+		// Sync and status controllers run on every replica, each
+		// reconciling the federated resources of the shards it holds.
+		startTypeConfigController(opts, stopChan)
+	}
+
 	elector, err := leaderelection.NewKubeFedLeaderElector(opts, startControllers)
 	if err != nil {
 		panic(err)
@@ -207,6 +223,12 @@ func startControllers(opts *options.Options, stopChan <-chan struct{}) {
 		}
 	}
 
+	if opts.Config.Shards == nil {
+		startTypeConfigController(opts, stopChan)
+	}
+}
+
+func startTypeConfigController(opts *options.Options, stopChan <-chan struct{}) {
 	if utilfeature.DefaultFeatureGate.Enabled(features.PushReconciler) {
 		if utilfeature.DefaultFeatureGate.Enabled(features.RawResourceStatusCollection) {
 			opts.Config.RawResourceStatusCollection = true
@@ -386,11 +408,25 @@ func setOptionsByKubeFedConfig(opts *options.Options) {
 	opts.ClusterHealthCheckConfig.Timeout = spec.ClusterHealthCheck.Timeout.Duration
 	opts.ClusterHealthCheckConfig.FailureThreshold = *spec.ClusterHealthCheck.FailureThreshold
 	opts.ClusterHealthCheckConfig.SuccessThreshold = *spec.ClusterHealthCheck.SuccessThreshold
+	// A KubeFedConfig created before the heartbeat mode was introduced
+	// may not have been defaulted.
+	healthCheckMode := spec.ClusterHealthCheck.Mode
+	if healthCheckMode != nil && *healthCheckMode == corev1b1.ClusterHealthCheckModeHeartbeat {
+		opts.ClusterHealthCheckConfig.Heartbeat = true
+		opts.ClusterHealthCheckConfig.MinReadyNodesPercent = *spec.ClusterHealthCheck.MinReadyNodesPercent
+		opts.ClusterHealthCheckConfig.CriticalComponents = spec.ClusterHealthCheck.CriticalComponents
+	}
 
 	opts.Config.MaxConcurrentSyncReconciles = *spec.SyncController.MaxConcurrentReconciles
 	opts.Config.MaxConcurrentStatusReconciles = *spec.StatusController.MaxConcurrentReconciles
 
 	opts.Config.SkipAdoptingResources = *spec.SyncController.AdoptResources == corev1b1.AdoptResourcesDisabled
+	// A KubeFedConfig created before namespace placement inheritance
+	// was introduced may not have been defaulted.
+	inheritance := spec.SyncController.NamespacePlacementInheritance
+	opts.Config.InheritNamespacePlacement = inheritance != nil && *inheritance == corev1b1.NamespacePlacementInheritanceEnabled
+	opts.Config.ClusterLimiters = utils.NewClusterLimiters(spec.SyncController.ClusterRateLimit)
+	opts.Sharding = spec.SyncController.Sharding
 
 	var featureGates = make(map[string]bool)
 	for _, v := range fedConfig.Spec.FeatureGates {
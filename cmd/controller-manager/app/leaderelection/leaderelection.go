@@ -32,11 +32,14 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubefed/cmd/controller-manager/app/options"
+	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1/defaults"
+	"sigs.k8s.io/kubefed/pkg/controller/utils/sharding"
 )
 
 const (
-	component = "kubefed-controller-manager"
-	userAgent = "kubefed-leader-election"
+	component      = "kubefed-controller-manager"
+	userAgent      = "kubefed-leader-election"
+	shardUserAgent = "kubefed-sharding"
 )
 
 func NewKubeFedLeaderElector(opts *options.Options, fnStartControllers func(*options.Options, <-chan struct{})) (*leaderelection.LeaderElector, error) {
@@ -46,9 +49,8 @@ func NewKubeFedLeaderElector(opts *options.Options, fnStartControllers func(*opt
 	// 初始化一个kubernetes的Clientset
 	leaderElectionClient := kubeclient.NewForConfigOrDie(kubeConfig)
 
-	hostname, err := os.Hostname()
+	id, err := newIdentity()
 	if err != nil {
-		klog.Infof("unable to get hostname: %v", err)
 		return nil, err
 	}
 
@@ -66,9 +68,6 @@ func NewKubeFedLeaderElector(opts *options.Options, fnStartControllers func(*opt
 	// 生成一个组件kubefed-controller-manager的事件记录
 	eventRecorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: component})
 
-	// 添加一个唯一标识符，以确保同一主机上的两个进程不会意外地都变为活跃状态
-	id := hostname + "_" + string(uuid.NewUUID())
-
 	// resourcelock.New 用于生成一个新的资源锁，确保在多个实例中只有一个被选为领导者
 	rl, err := resourcelock.New(
 		resourcelock.LeasesResourceLock,
@@ -117,3 +116,39 @@ func NewKubeFedLeaderElector(opts *options.Options, fnStartControllers func(*opt
 		},
 	})
 }
+
+// NewKubeFedShardManager 创建分片管理器，各副本通过 KubeFed 命名空间中每个分片的 Lease
+// 分担联邦资源的调和，租约时间与领导者选举相同
+func NewKubeFedShardManager(opts *options.Options) (*sharding.Manager, error) {
+	kubeConfig := restclient.CopyConfig(opts.Config.KubeConfig)
+	restclient.AddUserAgent(kubeConfig, shardUserAgent)
+	shardClient := kubeclient.NewForConfigOrDie(kubeConfig)
+
+	id, err := newIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	shards := int64(defaults.DefaultSyncControllerShards)
+	if opts.Sharding.Shards != nil {
+		shards = *opts.Sharding.Shards
+	}
+	return sharding.NewManager(shardClient.CoordinationV1(), id, sharding.Options{
+		Namespace:     opts.Config.KubeFedNamespace,
+		Mode:          opts.Sharding.Mode,
+		Shards:        int(shards),
+		LeaseDuration: opts.LeaderElection.LeaseDuration,
+		RenewDeadline: opts.LeaderElection.RenewDeadline,
+		RetryPeriod:   opts.LeaderElection.RetryPeriod,
+	}), nil
+}
+
+func newIdentity() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		klog.Infof("unable to get hostname: %v", err)
+		return "", err
+	}
+	// 添加一个唯一标识符，以确保同一主机上的两个进程不会意外地都变为活跃状态
+	return hostname + "_" + string(uuid.NewUUID()), nil
+}
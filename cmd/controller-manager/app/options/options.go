@@ -35,6 +35,7 @@ type Options struct {
 	Scope                    apiextv1.ResourceScope
 	LeaderElection           *utils.LeaderElectionConfiguration
 	ClusterHealthCheckConfig *utils.ClusterHealthCheckConfig
+	Sharding                 *fedv1b1.SyncSharding
 }
 
 // AddFlags adds flags to fs and binds them to options.
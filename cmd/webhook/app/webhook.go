@@ -35,6 +35,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+	"sigs.k8s.io/kubefed/pkg/controller/webhook/federatedresource"
 	"sigs.k8s.io/kubefed/pkg/controller/webhook/federatedtypeconfig"
 	"sigs.k8s.io/kubefed/pkg/controller/webhook/kubefedcluster"
 	"sigs.k8s.io/kubefed/pkg/controller/webhook/kubefedconfig"
@@ -42,10 +44,11 @@ import (
 )
 
 var (
-	certDir    string
-	kubeconfig string
-	masterURL  string
-	port       = 8443
+	certDir          string
+	kubeconfig       string
+	masterURL        string
+	kubeFedNamespace string
+	port             = 8443
 )
 
 // NewWebhookCommand creates a *cobra.Command object with default parameters
@@ -75,6 +78,7 @@ func NewWebhookCommand(stopChan <-chan struct{}) *cobra.Command {
 	flags.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 	flags.StringVar(&certDir, "cert-dir", "", "The directory where the TLS certs are located.")
 	flags.IntVar(&port, "secure-port", port, "The port on which to serve HTTPS.")
+	flags.StringVar(&kubeFedNamespace, "kubefed-namespace", utils.DefaultKubeFedSystemNamespace, "The namespace the KubeFed control plane is deployed in.")
 	flags.BoolVar(&verFlag, "version", false, "Prints the Version info of webhook.")
 	local := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 	klog.InitFlags(local)
@@ -111,6 +115,11 @@ func Run(stopChan <-chan struct{}) error {
 
 	hookServer.Register("/validate-federatedtypeconfigs", &webhook.Admission{Handler: &federatedtypeconfig.AdmissionHook{}})
 	hookServer.Register("/validate-kubefedcluster", &webhook.Admission{Handler: &kubefedcluster.AdmissionHook{}})
+	federatedResourceHook, err := federatedresource.NewAdmissionHook(config, kubeFedNamespace)
+	if err != nil {
+		klog.Fatalf("error setting up federated resource webhook: %s", err)
+	}
+	hookServer.Register("/validate-federatedresources", &webhook.Admission{Handler: federatedResourceHook})
 	hookServer.Register("/validate-kubefedconfig", &webhook.Admission{Handler: &kubefedconfig.Validator{}})
 	hookServer.Register("/default-kubefedconfig", &webhook.Admission{Handler: &kubefedconfig.KubeFedConfigDefaulter{}})
 
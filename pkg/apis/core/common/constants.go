@@ -26,6 +26,12 @@ const (
 	ClusterOffline ClusterConditionType = "Offline"
 	// ClusterConfigMalformed means the cluster's configuration may be malformed.
 	ClusterConfigMalformed ClusterConditionType = "ConfigMalformed"
+	// ClusterNodesHealthy means enough nodes of the cluster have renewed
+	// their Lease recently.
+	ClusterNodesHealthy ClusterConditionType = "NodesHealthy"
+	// ClusterComponentsHealthy means the critical components of the
+	// cluster have renewed their Lease recently.
+	ClusterComponentsHealthy ClusterConditionType = "ComponentsHealthy"
 )
 
 const (
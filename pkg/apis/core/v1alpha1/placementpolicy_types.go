@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PlacementPolicySpec defines the desired state of PlacementPolicy
+type PlacementPolicySpec struct {
+	// Placement determines the member clusters of the federated
+	// resources referencing the policy.  It supports the same fields
+	// as the placement of a federated resource.
+	Placement Placement `json:"placement"`
+}
+
+// Placement determines the member clusters a federated resource is
+// propagated to.
+type Placement struct {
+	// Clusters selected by name.  If provided and no operator is
+	// set, clusterSelector is ignored.  An empty list selects no
+	// clusters, so the field is serialized even when empty.
+	// +optional
+	Clusters []ClusterReference `json:"clusters"`
+
+	// ClusterSelector selects clusters by label.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// ExcludeClusters are never selected, regardless of the other
+	// fields.
+	// +optional
+	ExcludeClusters []ClusterReference `json:"excludeClusters,omitempty"`
+
+	// Operator combines clusters and clusterSelector.  This can be
+	// Union or Intersection.
+	// +kubebuilder:validation:Enum=Union;Intersection
+	// +optional
+	Operator string `json:"operator,omitempty"`
+
+	// Regions restrict placement to clusters in one of the regions.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// Zones restrict placement to clusters with nodes in one of the
+	// zones.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// SpreadConstraints bound the number of selected clusters and
+	// spread them across topology domains.
+	// +optional
+	SpreadConstraints *SpreadConstraints `json:"spreadConstraints,omitempty"`
+
+	// Tolerations allow placement in clusters with matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Failover names standby clusters used while too few of the
+	// selected clusters are ready.
+	// +optional
+	Failover *FailoverPolicy `json:"failover,omitempty"`
+}
+
+// ClusterReference references a member cluster by name.
+type ClusterReference struct {
+	// Name of the KubeFedCluster.
+	Name string `json:"name"`
+}
+
+// SpreadConstraints bound the number of selected clusters and spread
+// them across topology domains.
+type SpreadConstraints struct {
+	// MaxClusters is the maximum number of clusters selected.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClusters *int64 `json:"maxClusters,omitempty"`
+
+	// TopologyKey identifies the topology domain of a cluster.  The
+	// keys region and zone refer to the topology discovered in
+	// cluster status, and any other key refers to a cluster label.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// MaxPerTopology is the maximum number of clusters selected in a
+	// single topology domain.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPerTopology *int64 `json:"maxPerTopology,omitempty"`
+}
+
+// FailoverPolicy names standby clusters that are used in addition to
+// the selected clusters while too few of the selected clusters are
+// ready.
+type FailoverPolicy struct {
+	// MinReadyClusters is the number of selected clusters that must
+	// be ready for standby clusters not to be used.
+	// +kubebuilder:validation:Minimum=1
+	MinReadyClusters int64 `json:"minReadyClusters"`
+
+	// Clusters are standby clusters in order of preference.
+	// +optional
+	Clusters []ClusterReference `json:"clusters,omitempty"`
+
+	// ClusterSelector matches standby clusters that are used after
+	// the clusters listed by name.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// RevertGracePeriodSeconds is how long enough selected clusters
+	// must have been ready again before standby clusters are no
+	// longer used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevertGracePeriodSeconds *int64 `json:"revertGracePeriodSeconds,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=placementpolicies,shortName=pp
+
+// PlacementPolicy defines placement shared by federated resources.
+// A federated resource references a PlacementPolicy in its own
+// namespace with spec.placementRef, or a PlacementPolicy in the
+// KubeFed system namespace if the federated resource is
+// cluster-scoped.  The placement of the policy is used instead of the
+// placement of the federated resource.
+type PlacementPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PlacementPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlacementPolicyList contains a list of PlacementPolicy
+type PlacementPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlacementPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PlacementPolicy{}, &PlacementPolicyList{})
+}
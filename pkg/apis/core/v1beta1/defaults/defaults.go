@@ -41,7 +41,10 @@ const (
 	DefaultClusterHealthCheckSuccessThreshold = 1
 	DefaultClusterHealthCheckTimeout          = 3 * time.Second
 
+	DefaultClusterHealthCheckMinReadyNodesPercent = 90
+
 	DefaultSyncControllerMaxConcurrentReconciles   = 1
+	DefaultSyncControllerShards                    = 16
 	DefaultStatusControllerMaxConcurrentReconciles = 1
 )
 
@@ -87,6 +90,19 @@ func SetDefaultKubeFedConfig(fedConfig *v1beta1.KubeFedConfig) {
 	setDuration(&healthCheck.Timeout, DefaultClusterHealthCheckTimeout)
 	setInt64(&healthCheck.FailureThreshold, DefaultClusterHealthCheckFailureThreshold)
 	setInt64(&healthCheck.SuccessThreshold, DefaultClusterHealthCheckSuccessThreshold)
+	if healthCheck.Mode == nil {
+		healthCheck.Mode = new(v1beta1.ClusterHealthCheckMode)
+		*healthCheck.Mode = v1beta1.ClusterHealthCheckModeProbe
+	}
+	if *healthCheck.Mode == v1beta1.ClusterHealthCheckModeHeartbeat {
+		setInt64(&healthCheck.MinReadyNodesPercent, DefaultClusterHealthCheckMinReadyNodesPercent)
+		if healthCheck.CriticalComponents == nil {
+			healthCheck.CriticalComponents = []v1beta1.ClusterComponent{
+				{Namespace: metav1.NamespaceSystem, Name: "kube-controller-manager"},
+				{Namespace: metav1.NamespaceSystem, Name: "kube-scheduler"},
+			}
+		}
+	}
 
 	if spec.SyncController == nil {
 		spec.SyncController = &v1beta1.SyncControllerConfig{}
@@ -99,6 +115,15 @@ func SetDefaultKubeFedConfig(fedConfig *v1beta1.KubeFedConfig) {
 		*spec.SyncController.AdoptResources = v1beta1.AdoptResourcesEnabled
 	}
 
+	if spec.SyncController.NamespacePlacementInheritance == nil {
+		spec.SyncController.NamespacePlacementInheritance = new(v1beta1.NamespacePlacementInheritance)
+		*spec.SyncController.NamespacePlacementInheritance = v1beta1.NamespacePlacementInheritanceDisabled
+	}
+
+	if spec.SyncController.Sharding != nil {
+		setInt64(&spec.SyncController.Sharding.Shards, DefaultSyncControllerShards)
+	}
+
 	if spec.StatusController == nil {
 		spec.StatusController = &v1beta1.StatusControllerConfig{}
 	}
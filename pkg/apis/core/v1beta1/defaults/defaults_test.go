@@ -125,6 +125,15 @@ func TestSetDefaultKubeFedConfig(t *testing.T) {
 	SetDefaultKubeFedConfig(modifiedTimeoutKFC)
 	successCases["spec.clusterHealthCheck.timeout is preserved"] = KubeFedConfigComparison{timeoutKFC, modifiedTimeoutKFC}
 
+	heartbeatKFC := defaultKubeFedConfig()
+	*heartbeatKFC.Spec.ClusterHealthCheck.Mode = v1beta1.ClusterHealthCheckModeHeartbeat
+	minReadyNodesPercent := int64(DefaultClusterHealthCheckMinReadyNodesPercent - 40)
+	heartbeatKFC.Spec.ClusterHealthCheck.MinReadyNodesPercent = &minReadyNodesPercent
+	heartbeatKFC.Spec.ClusterHealthCheck.CriticalComponents = []v1beta1.ClusterComponent{{Namespace: "ingress", Name: "ingress-controller-leader"}}
+	modifiedHeartbeatKFC := heartbeatKFC.DeepCopyObject().(*v1beta1.KubeFedConfig)
+	SetDefaultKubeFedConfig(modifiedHeartbeatKFC)
+	successCases["spec.clusterHealthCheck heartbeat settings are preserved"] = KubeFedConfigComparison{heartbeatKFC, modifiedHeartbeatKFC}
+
 	// SyncController
 	syncControllerMaxConcurrentReconcilesKFC := defaultKubeFedConfig()
 	syncControllerMaxConcurrentReconciles := int64(DefaultSyncControllerMaxConcurrentReconciles + 3)
@@ -139,6 +148,12 @@ func TestSetDefaultKubeFedConfig(t *testing.T) {
 	SetDefaultKubeFedConfig(modifiedAdoptResourcesKFC)
 	successCases["spec.syncController.adoptResources is preserved"] = KubeFedConfigComparison{adoptResourcesKFC, modifiedAdoptResourcesKFC}
 
+	namespacePlacementInheritanceKFC := defaultKubeFedConfig()
+	*namespacePlacementInheritanceKFC.Spec.SyncController.NamespacePlacementInheritance = v1beta1.NamespacePlacementInheritanceEnabled
+	modifiedNamespacePlacementInheritanceKFC := namespacePlacementInheritanceKFC.DeepCopyObject().(*v1beta1.KubeFedConfig)
+	SetDefaultKubeFedConfig(modifiedNamespacePlacementInheritanceKFC)
+	successCases["spec.syncController.namespacePlacementInheritance is preserved"] = KubeFedConfigComparison{namespacePlacementInheritanceKFC, modifiedNamespacePlacementInheritanceKFC}
+
 	// StatusController
 	statusControllerMaxConcurrentReconcilesKFC := defaultKubeFedConfig()
 	statusControllerMaxConcurrentReconciles := int64(DefaultStatusControllerMaxConcurrentReconciles + 3)
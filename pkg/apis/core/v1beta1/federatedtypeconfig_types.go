@@ -47,6 +47,53 @@ type FederatedTypeConfigSpec struct {
 	// Whether or not Status object should be populated.
 	// +optional
 	StatusCollection *StatusCollectionMode `json:"statusCollection,omitempty"`
+	// How resources are written to member clusters. Update (the default)
+	// replaces resources with an update that retains a fixed set of fields
+	// from member clusters. ServerSideApply applies resources with a
+	// dedicated field manager so that fields owned by controllers in member
+	// clusters are left alone.
+	// +optional
+	ApplyStrategy *ApplyStrategy `json:"applyStrategy,omitempty"`
+	// JSON pointers (e.g. /spec/clusterIP) to fields whose values should be
+	// retained from the resources in member clusters when they are updated.
+	// Retained values replace the values of the template, but may still be
+	// overridden. Fields within arrays, and pointers with numeric tokens that
+	// would index into them, are not supported.
+	// +optional
+	RetainFields []string `json:"retainFields,omitempty"`
+	// How resources in member clusters are cached by the controllers.
+	// Full (the default) caches complete resources. Minimal caches only
+	// the metadata (without managed fields) and status of resources and
+	// the few fields of their spec needed to propagate them, which
+	// reduces memory use for types with large payloads such as ConfigMaps
+	// and Secrets.
+	// +optional
+	CacheMode *CacheMode `json:"cacheMode,omitempty"`
+	// The priorities with which the sync controller reconciles
+	// federated resources of the type, by the reason they need to be
+	// reconciled.  By default changes to federated resources are
+	// reconciled ahead of reconciliations triggered by changes to
+	// member clusters, which are reconciled ahead of retries.
+	// +optional
+	SyncPriorities *SyncPriorities `json:"syncPriorities,omitempty"`
+}
+
+// SyncPriorities assigns priorities to the reconciliations of the sync
+// controller.
+type SyncPriorities struct {
+	// The priority of reconciling a federated resource after it was
+	// changed. Defaults to "High".
+	// +optional
+	UserChange *SyncPriority `json:"userChange,omitempty"`
+	// The priority of reconciling federated resources after a member
+	// cluster became available or unavailable. Defaults to "Medium".
+	// +optional
+	ClusterSync *SyncPriority `json:"clusterSync,omitempty"`
+	// The priority of reconciling a federated resource again after an
+	// error, a change to a resource in a member cluster or a delay.
+	// Defaults to "Low".
+	// +optional
+	Retry *SyncPriority `json:"retry,omitempty"`
 }
 
 // APIResource defines how to configure the dynamic client for an API resource.
@@ -85,6 +132,32 @@ const (
 	StatusCollectionDisabled StatusCollectionMode = "Disabled"
 )
 
+// ApplyStrategy defines how resources are written to member clusters.
+type ApplyStrategy string
+
+const (
+	ApplyStrategyUpdate          ApplyStrategy = "Update"
+	ApplyStrategyServerSideApply ApplyStrategy = "ServerSideApply"
+)
+
+// CacheMode defines how resources in member clusters are cached.
+type CacheMode string
+
+const (
+	CacheModeFull    CacheMode = "Full"
+	CacheModeMinimal CacheMode = "Minimal"
+)
+
+// SyncPriority defines the priority of a reconciliation of the sync
+// controller.
+type SyncPriority string
+
+const (
+	SyncPriorityHigh   SyncPriority = "High"
+	SyncPriorityMedium SyncPriority = "Medium"
+	SyncPriorityLow    SyncPriority = "Low"
+)
+
 // ControllerStatus defines the current state of the controller
 type ControllerStatus string
 
@@ -223,6 +296,24 @@ func (f *FederatedTypeConfig) GetStatusEnabled() bool {
 		*f.Spec.StatusCollection == StatusCollectionEnabled
 }
 
+func (f *FederatedTypeConfig) GetServerSideApplyEnabled() bool {
+	return f.Spec.ApplyStrategy != nil &&
+		*f.Spec.ApplyStrategy == ApplyStrategyServerSideApply
+}
+
+func (f *FederatedTypeConfig) GetMinimalCacheEnabled() bool {
+	return f.Spec.CacheMode != nil &&
+		*f.Spec.CacheMode == CacheModeMinimal
+}
+
+func (f *FederatedTypeConfig) GetRetainFields() []string {
+	return f.Spec.RetainFields
+}
+
+func (f *FederatedTypeConfig) GetSyncPriorities() *SyncPriorities {
+	return f.Spec.SyncPriorities
+}
+
 // TODO(font): This method should be removed from the interface i.e. remove
 // special-case handling for namespaces, in favor of checking the namespaced
 // property of the appropriate APIResource (TargetType, FederatedType)
@@ -55,6 +55,21 @@ type KubeFedClusterSpec struct {
 	// ProxyURL allows to set proxy URL for the cluster.
 	// +optional
 	ProxyURL string `json:"proxyURL"`
+
+	// Taints prevent federated resources from being placed in the
+	// cluster unless they tolerate the taint. Supported effects are
+	// NoSchedule, which stops new placement while leaving existing
+	// resources in place, and NoExecute, which also removes resources
+	// already propagated to the cluster.
+	// +optional
+	Taints []apiv1.Taint `json:"taints,omitempty"`
+
+	// PropagationRateLimit limits the rate and concurrency of the
+	// operations propagated to the cluster.  Limits that are not set
+	// default to the clusterRateLimit of the sync controller
+	// configured in KubeFedConfig.
+	// +optional
+	PropagationRateLimit *PropagationRateLimit `json:"propagationRateLimit,omitempty"`
 }
 
 // LocalSecretReference is a reference to a secret within the enclosing
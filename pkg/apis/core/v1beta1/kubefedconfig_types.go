@@ -109,6 +109,40 @@ type ClusterHealthCheckConfig struct {
 	// Duration after which the cluster health check times out.
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// How the health of member clusters is determined.  Probe only polls
+	// the /healthz endpoint of each cluster.  Heartbeat also watches the
+	// Leases renewed by the nodes and critical components of each
+	// cluster, and reports whether they are healthy in the NodesHealthy
+	// and ComponentsHealthy conditions of the cluster.  Defaults to
+	// "Probe".
+	// +optional
+	Mode *ClusterHealthCheckMode `json:"mode,omitempty"`
+	// The minimum percentage of the nodes of a cluster that must have
+	// renewed their Lease for the nodes of the cluster to be healthy.
+	// Only used by the Heartbeat mode.  Defaults to 90.
+	// +optional
+	MinReadyNodesPercent *int64 `json:"minReadyNodesPercent,omitempty"`
+	// The critical components of member clusters, each identified by a
+	// Lease it renews.  Only used by the Heartbeat mode.  Defaults to the
+	// Leases of kube-controller-manager and kube-scheduler.
+	// +optional
+	CriticalComponents []ClusterComponent `json:"criticalComponents,omitempty"`
+}
+
+type ClusterHealthCheckMode string
+
+const (
+	ClusterHealthCheckModeProbe     ClusterHealthCheckMode = "Probe"
+	ClusterHealthCheckModeHeartbeat ClusterHealthCheckMode = "Heartbeat"
+)
+
+// ClusterComponent identifies a component of member clusters by the
+// Lease it renews, typically the Lease of its leader election.
+type ClusterComponent struct {
+	// Namespace of the Lease in member clusters.
+	Namespace string `json:"namespace"`
+	// Name of the Lease.
+	Name string `json:"name"`
 }
 
 type SyncControllerConfig struct {
@@ -120,6 +154,56 @@ type SyncControllerConfig struct {
 	// "Enabled".
 	// +optional
 	AdoptResources *ResourceAdoption `json:"adoptResources,omitempty"`
+	// Whether namespaced federated resources without placement inherit
+	// the placement of their federated namespace.  Can be overridden
+	// per resource or per namespace by the
+	// "kubefed.io/inherit-namespace-placement" annotation.  Defaults
+	// to "Disabled".
+	// +optional
+	NamespacePlacementInheritance *NamespacePlacementInheritance `json:"namespacePlacementInheritance,omitempty"`
+	// The default limits on the rate and concurrency of the operations
+	// the sync controllers propagate to each member cluster.  Limits
+	// are shared by the sync controllers of all federated types and
+	// can be overridden per cluster by the propagationRateLimit field
+	// of KubeFedCluster.  Unset limits are not enforced.
+	// +optional
+	ClusterRateLimit *PropagationRateLimit `json:"clusterRateLimit,omitempty"`
+	// Sharding splits the reconciliation of federated resources between
+	// the replicas of the controller manager.  When unset, federated
+	// resources are only reconciled by the elected leader.
+	// +optional
+	Sharding *SyncSharding `json:"sharding,omitempty"`
+}
+
+// SyncSharding assigns federated resources to shards that are each
+// reconciled by a single replica of the controller manager at a time.
+type SyncSharding struct {
+	// How federated resources are assigned to shards.  TypeConfig
+	// assigns all the federated resources of a type to the same shard.
+	// Resource assigns federated resources by a hash of their namespace
+	// and name.
+	Mode ShardingMode `json:"mode"`
+	// The number of shards, each held by a replica through a Lease in
+	// the KubeFed namespace.  Defaults to 16.
+	// +optional
+	Shards *int64 `json:"shards,omitempty"`
+}
+
+// PropagationRateLimit limits the operations propagated to a member
+// cluster.
+type PropagationRateLimit struct {
+	// The number of operations per second that may be propagated to a
+	// member cluster.
+	// +optional
+	QPS *int64 `json:"qps,omitempty"`
+	// The maximum number of operations that may be propagated to a
+	// member cluster in a single burst.  Defaults to the value of qps.
+	// +optional
+	Burst *int64 `json:"burst,omitempty"`
+	// The maximum number of operations that may be in flight against a
+	// member cluster at any time.
+	// +optional
+	MaxInflight *int64 `json:"maxInflight,omitempty"`
 }
 
 type ResourceAdoption string
@@ -129,6 +213,20 @@ const (
 	AdoptResourcesDisabled ResourceAdoption = "Disabled"
 )
 
+type ShardingMode string
+
+const (
+	ShardingModeTypeConfig ShardingMode = "TypeConfig"
+	ShardingModeResource   ShardingMode = "Resource"
+)
+
+type NamespacePlacementInheritance string
+
+const (
+	NamespacePlacementInheritanceEnabled  NamespacePlacementInheritance = "Enabled"
+	NamespacePlacementInheritanceDisabled NamespacePlacementInheritance = "Disabled"
+)
+
 type StatusControllerConfig struct {
 	// The maximum number of concurrent Reconciles of status controller which can be run.
 	// Defaults to 1.
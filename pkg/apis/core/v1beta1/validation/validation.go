@@ -74,6 +74,19 @@ func ValidateFederatedTypeConfigSpec(spec *v1beta1.FederatedTypeConfigSpec, fldP
 		allErrs = append(allErrs, validateEnumStrings(fldPath.Child("statusCollection"), string(*spec.StatusCollection), []string{string(v1beta1.StatusCollectionEnabled), string(v1beta1.StatusCollectionDisabled)})...)
 	}
 
+	if spec.ApplyStrategy != nil {
+		allErrs = append(allErrs, validateEnumStrings(fldPath.Child("applyStrategy"), string(*spec.ApplyStrategy), []string{string(v1beta1.ApplyStrategyUpdate), string(v1beta1.ApplyStrategyServerSideApply)})...)
+	}
+	if spec.CacheMode != nil {
+		allErrs = append(allErrs, validateEnumStrings(fldPath.Child("cacheMode"), string(*spec.CacheMode), []string{string(v1beta1.CacheModeFull), string(v1beta1.CacheModeMinimal)})...)
+	}
+
+	allErrs = append(allErrs, validateRetainFields(spec.RetainFields, fldPath.Child("retainFields"))...)
+
+	if spec.SyncPriorities != nil {
+		allErrs = append(allErrs, validateSyncPriorities(spec.SyncPriorities, fldPath.Child("syncPriorities"))...)
+	}
+
 	return allErrs
 }
 
@@ -141,6 +154,64 @@ func validateEnumStrings(fldPath *field.Path, value string, accepted []string) f
 	return field.ErrorList{field.NotSupported(fldPath, value, accepted)}
 }
 
+// invalidRetainFields are the paths that identify a resource and may
+// not be retained from member clusters.
+var invalidRetainFields = []string{"/apiVersion", "/kind", "/metadata", "/metadata/name", "/metadata/namespace"}
+
+func validateRetainFields(retainFields []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := make(map[string]bool)
+	for i, path := range retainFields {
+		idxPath := fldPath.Index(i)
+		if !strings.HasPrefix(path, "/") || strings.Contains(path+"/", "//") {
+			allErrs = append(allErrs, field.Invalid(idxPath, path, "must be a JSON pointer to a field, e.g. /spec/clusterIP"))
+			continue
+		}
+		for _, invalidPath := range invalidRetainFields {
+			if path == invalidPath {
+				allErrs = append(allErrs, field.Invalid(idxPath, path, "may not be retained"))
+			}
+		}
+		if isArrayPointer(path) {
+			allErrs = append(allErrs, field.Invalid(idxPath, path, "fields within arrays may not be retained"))
+		}
+		if seen[path] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, path))
+		}
+		seen[path] = true
+	}
+	return allErrs
+}
+
+// isArrayPointer returns whether the given JSON pointer has a token
+// that indexes into an array.
+func isArrayPointer(path string) bool {
+	for _, token := range strings.Split(path[1:], "/") {
+		if token == "-" {
+			return true
+		}
+		if _, err := strconv.ParseUint(token, 10, 64); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSyncPriorities(priorities *v1beta1.SyncPriorities, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	accepted := []string{string(v1beta1.SyncPriorityHigh), string(v1beta1.SyncPriorityMedium), string(v1beta1.SyncPriorityLow)}
+	if priorities.UserChange != nil {
+		allErrs = append(allErrs, validateEnumStrings(fldPath.Child("userChange"), string(*priorities.UserChange), accepted)...)
+	}
+	if priorities.ClusterSync != nil {
+		allErrs = append(allErrs, validateEnumStrings(fldPath.Child("clusterSync"), string(*priorities.ClusterSync), accepted)...)
+	}
+	if priorities.Retry != nil {
+		allErrs = append(allErrs, validateEnumStrings(fldPath.Child("retry"), string(*priorities.Retry), accepted)...)
+	}
+	return allErrs
+}
+
 func ValidateFederatedTypeConfigStatus(status *v1beta1.FederatedTypeConfigStatus, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -170,6 +241,25 @@ func validateKubeFedClusterSpec(spec *v1beta1.KubeFedClusterSpec, path *field.Pa
 	if spec.ProxyURL != "" {
 		allErrs = append(allErrs, validateProxyURL(spec.ProxyURL, path.Child("proxyURL"))...)
 	}
+	allErrs = append(allErrs, validateTaints(spec.Taints, path.Child("taints"))...)
+	allErrs = append(allErrs, validatePropagationRateLimit(spec.PropagationRateLimit, path.Child("propagationRateLimit"))...)
+	return allErrs
+}
+
+func validatePropagationRateLimit(limit *v1beta1.PropagationRateLimit, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if limit == nil {
+		return allErrs
+	}
+	if limit.QPS != nil {
+		allErrs = append(allErrs, validateGreaterThan0(path.Child("qps"), *limit.QPS)...)
+	}
+	if limit.Burst != nil {
+		allErrs = append(allErrs, validateGreaterThan0(path.Child("burst"), *limit.Burst)...)
+	}
+	if limit.MaxInflight != nil {
+		allErrs = append(allErrs, validateGreaterThan0(path.Child("maxInflight"), *limit.MaxInflight)...)
+	}
 	return allErrs
 }
 
@@ -255,10 +345,42 @@ func validateDisabledTLSValidations(disabledTLSValidations []v1beta1.TLSValidati
 	return allErrs
 }
 
+func validateTaints(taints []corev1.Taint, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	type taintID struct {
+		key    string
+		effect corev1.TaintEffect
+	}
+	seen := make(map[taintID]bool)
+	for i, taint := range taints {
+		idxPath := path.Index(i)
+		for _, msg := range valutil.IsQualifiedName(taint.Key) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("key"), taint.Key, msg))
+		}
+		if taint.Value != "" {
+			for _, msg := range valutil.IsValidLabelValue(taint.Value) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), taint.Value, msg))
+			}
+		}
+		allErrs = append(allErrs, validateEnumStrings(idxPath.Child("effect"), string(taint.Effect),
+			[]string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectNoExecute)})...)
+
+		id := taintID{key: taint.Key, effect: taint.Effect}
+		if seen[id] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, taint.Key+":"+string(taint.Effect)))
+		}
+		seen[id] = true
+	}
+
+	return allErrs
+}
+
 func validateClusterCondition(cc *v1beta1.ClusterCondition, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
-	allErrs = append(allErrs, validateEnumStrings(path.Child("type"), string(cc.Type), []string{string(common.ClusterReady), string(common.ClusterOffline), string(common.ClusterConfigMalformed)})...)
+	allErrs = append(allErrs, validateEnumStrings(path.Child("type"), string(cc.Type), []string{string(common.ClusterReady), string(common.ClusterOffline), string(common.ClusterConfigMalformed),
+		string(common.ClusterNodesHealthy), string(common.ClusterComponentsHealthy)})...)
 	allErrs = append(allErrs, validateEnumStrings(path.Child("status"), string(cc.Status), []string{string(corev1.ConditionTrue), string(corev1.ConditionFalse), string(corev1.ConditionUnknown)})...)
 
 	if cc.LastProbeTime.IsZero() {
@@ -352,6 +474,28 @@ func ValidateKubeFedConfig(kubeFedConfig, oldKubeFedConfig *v1beta1.KubeFedConfi
 		allErrs = append(allErrs, validateIntPtrGreaterThan0(healthPath.Child("failureThreshold"), health.FailureThreshold)...)
 		allErrs = append(allErrs, validateIntPtrGreaterThan0(healthPath.Child("successThreshold"), health.SuccessThreshold)...)
 		allErrs = append(allErrs, validateDurationGreaterThan0(healthPath.Child("timeout"), health.Timeout)...)
+		if health.Mode != nil {
+			allErrs = append(allErrs, validateEnumStrings(healthPath.Child("mode"), string(*health.Mode),
+				[]string{string(v1beta1.ClusterHealthCheckModeProbe), string(v1beta1.ClusterHealthCheckModeHeartbeat)})...)
+		}
+		minReadyNodesPath := healthPath.Child("minReadyNodesPercent")
+		if health.MinReadyNodesPercent != nil {
+			percent := *health.MinReadyNodesPercent
+			if percent < 0 || percent > 100 {
+				allErrs = append(allErrs, field.Invalid(minReadyNodesPath, percent, "should be between 0 and 100"))
+			}
+		} else if health.Mode != nil && *health.Mode == v1beta1.ClusterHealthCheckModeHeartbeat {
+			allErrs = append(allErrs, field.Required(minReadyNodesPath, ""))
+		}
+		for i, component := range health.CriticalComponents {
+			componentPath := healthPath.Child("criticalComponents").Index(i)
+			if component.Namespace == "" {
+				allErrs = append(allErrs, field.Required(componentPath.Child("namespace"), ""))
+			}
+			if component.Name == "" {
+				allErrs = append(allErrs, field.Required(componentPath.Child("name"), ""))
+			}
+		}
 	}
 
 	sync := spec.SyncController
@@ -366,6 +510,17 @@ func ValidateKubeFedConfig(kubeFedConfig, oldKubeFedConfig *v1beta1.KubeFedConfi
 		allErrs = append(allErrs, validateIntPtrGreaterThan0(syncPath.Child("maxConcurrentReconciles"), sync.MaxConcurrentReconciles)...)
 		allErrs = append(allErrs, validateEnumStrings(adoptPath, string(*sync.AdoptResources),
 			[]string{string(v1beta1.AdoptResourcesEnabled), string(v1beta1.AdoptResourcesDisabled)})...)
+		if sync.NamespacePlacementInheritance != nil {
+			allErrs = append(allErrs, validateEnumStrings(syncPath.Child("namespacePlacementInheritance"), string(*sync.NamespacePlacementInheritance),
+				[]string{string(v1beta1.NamespacePlacementInheritanceEnabled), string(v1beta1.NamespacePlacementInheritanceDisabled)})...)
+		}
+		allErrs = append(allErrs, validatePropagationRateLimit(sync.ClusterRateLimit, syncPath.Child("clusterRateLimit"))...)
+		if sync.Sharding != nil {
+			shardingPath := syncPath.Child("sharding")
+			allErrs = append(allErrs, validateEnumStrings(shardingPath.Child("mode"), string(sync.Sharding.Mode),
+				[]string{string(v1beta1.ShardingModeTypeConfig), string(v1beta1.ShardingModeResource)})...)
+			allErrs = append(allErrs, validateIntPtrGreaterThan0(shardingPath.Child("shards"), sync.Sharding.Shards)...)
+		}
 	}
 
 	statusController := spec.StatusController
@@ -120,6 +120,37 @@ func TestValidateFederatedTypeConfigSpec(t *testing.T) {
 	invalidStatusCollection.Spec.StatusCollection = &invalidStatusCollectionMode
 	errorCases["spec.statusCollection: Unsupported value"] = invalidStatusCollection
 
+	invalidApplyStrategy := validFederatedTypeConfig()
+	var invalidApplyStrategyValue v1beta1.ApplyStrategy = "InvalidApplyStrategy"
+	invalidApplyStrategy.Spec.ApplyStrategy = &invalidApplyStrategyValue
+	errorCases["spec.applyStrategy: Unsupported value"] = invalidApplyStrategy
+
+	invalidRetainFieldsPointer := validFederatedTypeConfig()
+	invalidRetainFieldsPointer.Spec.RetainFields = []string{"spec/clusterIP"}
+	errorCases["spec.retainFields[0]: Invalid value"] = invalidRetainFieldsPointer
+
+	invalidRetainFieldsName := validFederatedTypeConfig()
+	invalidRetainFieldsName.Spec.RetainFields = []string{"/spec/clusterIP", "/metadata/name"}
+	errorCases["spec.retainFields[1]: Invalid value"] = invalidRetainFieldsName
+
+	duplicateRetainFields := validFederatedTypeConfig()
+	duplicateRetainFields.Spec.RetainFields = []string{"/spec/clusterIP", "/spec/clusterIP"}
+	errorCases["spec.retainFields[1]: Duplicate value"] = duplicateRetainFields
+
+	arrayRetainFields := validFederatedTypeConfig()
+	arrayRetainFields.Spec.RetainFields = []string{"/spec/clusterIP", "/spec/replicas", "/spec/ports/0/nodePort"}
+	errorCases["spec.retainFields[2]: Invalid value"] = arrayRetainFields
+
+	invalidCacheMode := validFederatedTypeConfig()
+	invalidCacheModeValue := v1beta1.CacheMode("Partial")
+	invalidCacheMode.Spec.CacheMode = &invalidCacheModeValue
+	errorCases["spec.cacheMode: Unsupported value"] = invalidCacheMode
+
+	invalidSyncPriority := validFederatedTypeConfig()
+	invalidSyncPriorityValue := v1beta1.SyncPriority("Urgent")
+	invalidSyncPriority.Spec.SyncPriorities = &v1beta1.SyncPriorities{Retry: &invalidSyncPriorityValue}
+	errorCases["spec.syncPriorities.retry: Unsupported value"] = invalidSyncPriority
+
 	for k, v := range errorCases {
 		errs := ValidateFederatedTypeConfigSpec(&v.Spec, field.NewPath("spec"))
 		if len(errs) == 0 {
@@ -616,6 +647,122 @@ func TestDisabledTLSValidations(t *testing.T) {
 	}
 }
 
+func TestValidateTaints(t *testing.T) {
+	testCases := []struct {
+		taints         []corev1.Taint
+		expectedErr    bool
+		expectedErrMsg string
+	}{
+		{
+			[]corev1.Taint{},
+			false,
+			"",
+		},
+		{
+			[]corev1.Taint{{Key: "maintenance", Value: "true", Effect: corev1.TaintEffectNoSchedule}},
+			false,
+			"",
+		},
+		{
+			[]corev1.Taint{
+				{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoExecute},
+			},
+			false,
+			"",
+		},
+		{
+			[]corev1.Taint{{Key: "maintenance", Effect: corev1.TaintEffectPreferNoSchedule}},
+			true,
+			"Unsupported value",
+		},
+		{
+			[]corev1.Taint{{Key: "maintenance"}},
+			true,
+			"Required value",
+		},
+		{
+			[]corev1.Taint{{Key: "-maintenance", Effect: corev1.TaintEffectNoSchedule}},
+			true,
+			"name part must consist of alphanumeric characters",
+		},
+		{
+			[]corev1.Taint{{Key: "maintenance", Value: "not valid", Effect: corev1.TaintEffectNoSchedule}},
+			true,
+			"a valid label must be an empty string or consist of alphanumeric characters",
+		},
+		{
+			[]corev1.Taint{
+				{Key: "maintenance", Effect: corev1.TaintEffectNoExecute},
+				{Key: "maintenance", Value: "true", Effect: corev1.TaintEffectNoExecute},
+			},
+			true,
+			"Duplicate value",
+		},
+	}
+
+	for _, test := range testCases {
+		errs := validateTaints(test.taints, field.NewPath("taints"))
+		hasErr := len(errs) > 0
+		if hasErr != test.expectedErr {
+			t.Errorf("[%s] expected failure: %v, got errors: %v", test.expectedErrMsg, test.expectedErr, errs)
+		} else if hasErr && !strings.Contains(errs[0].Error(), test.expectedErrMsg) {
+			t.Errorf("unexpected error: %v, expected: %q", errs[0].Error(), test.expectedErrMsg)
+		}
+	}
+}
+
+func TestValidatePropagationRateLimit(t *testing.T) {
+	validValue := int64(10)
+	invalidValue := int64(0)
+	testCases := []struct {
+		limit          *v1beta1.PropagationRateLimit
+		expectedErr    bool
+		expectedErrMsg string
+	}{
+		{
+			nil,
+			false,
+			"",
+		},
+		{
+			&v1beta1.PropagationRateLimit{QPS: &validValue, Burst: &validValue, MaxInflight: &validValue},
+			false,
+			"",
+		},
+		{
+			&v1beta1.PropagationRateLimit{MaxInflight: &validValue},
+			false,
+			"",
+		},
+		{
+			&v1beta1.PropagationRateLimit{QPS: &invalidValue},
+			true,
+			"propagationRateLimit.qps: Invalid value",
+		},
+		{
+			&v1beta1.PropagationRateLimit{QPS: &validValue, Burst: &invalidValue},
+			true,
+			"propagationRateLimit.burst: Invalid value",
+		},
+		{
+			&v1beta1.PropagationRateLimit{MaxInflight: &invalidValue},
+			true,
+			"propagationRateLimit.maxInflight: Invalid value",
+		},
+	}
+
+	for _, test := range testCases {
+		errs := validatePropagationRateLimit(test.limit, field.NewPath("propagationRateLimit"))
+		hasErr := len(errs) > 0
+		if hasErr != test.expectedErr {
+			t.Errorf("[%s] expected failure: %v, got errors: %v", test.expectedErrMsg, test.expectedErr, errs)
+		} else if hasErr && !strings.Contains(errs[0].Error(), test.expectedErrMsg) {
+			t.Errorf("unexpected error: %v, expected: %q", errs[0].Error(), test.expectedErrMsg)
+		}
+	}
+}
+
 func TestValidateClusterCondition(t *testing.T) {
 	testCases := []struct {
 		cc             *v1beta1.ClusterCondition
@@ -848,6 +995,25 @@ func TestValidateKubeFedConfig(t *testing.T) {
 	invalidTimeoutGreaterThan0.Spec.ClusterHealthCheck.Timeout.Duration = 0
 	errorCases["spec.clusterHealthCheck.timeout: Invalid value"] = invalidTimeoutGreaterThan0
 
+	invalidHealthCheckMode := testcommon.ValidKubeFedConfig()
+	invalidHealthCheckModeValue := v1beta1.ClusterHealthCheckMode("Agent")
+	invalidHealthCheckMode.Spec.ClusterHealthCheck.Mode = &invalidHealthCheckModeValue
+	errorCases["spec.clusterHealthCheck.mode: Unsupported value"] = invalidHealthCheckMode
+
+	invalidMinReadyNodesPercent := testcommon.ValidKubeFedConfig()
+	invalidMinReadyNodesPercentValue := int64(101)
+	invalidMinReadyNodesPercent.Spec.ClusterHealthCheck.MinReadyNodesPercent = &invalidMinReadyNodesPercentValue
+	errorCases["spec.clusterHealthCheck.minReadyNodesPercent: Invalid value"] = invalidMinReadyNodesPercent
+
+	invalidMinReadyNodesPercentNil := testcommon.ValidKubeFedConfig()
+	heartbeatMode := v1beta1.ClusterHealthCheckModeHeartbeat
+	invalidMinReadyNodesPercentNil.Spec.ClusterHealthCheck.Mode = &heartbeatMode
+	errorCases["spec.clusterHealthCheck.minReadyNodesPercent: Required value"] = invalidMinReadyNodesPercentNil
+
+	invalidCriticalComponentName := testcommon.ValidKubeFedConfig()
+	invalidCriticalComponentName.Spec.ClusterHealthCheck.CriticalComponents = []v1beta1.ClusterComponent{{Namespace: "kube-system"}}
+	errorCases["spec.clusterHealthCheck.criticalComponents[0].name: Required value"] = invalidCriticalComponentName
+
 	invalidSyncControllerNil := testcommon.ValidKubeFedConfig()
 	invalidSyncControllerNil.Spec.SyncController = nil
 	errorCases["spec.syncController: Required value"] = invalidSyncControllerNil
@@ -869,6 +1035,23 @@ func TestValidateKubeFedConfig(t *testing.T) {
 	invalidAdoptResources.Spec.SyncController.AdoptResources = &invalidAdoptResourcesValue
 	errorCases["spec.syncController.adoptResources: Unsupported value"] = invalidAdoptResources
 
+	invalidNamespacePlacementInheritance := testcommon.ValidKubeFedConfig()
+	invalidNamespacePlacementInheritanceValue := v1beta1.NamespacePlacementInheritance("Sometimes")
+	invalidNamespacePlacementInheritance.Spec.SyncController.NamespacePlacementInheritance = &invalidNamespacePlacementInheritanceValue
+	errorCases["spec.syncController.namespacePlacementInheritance: Unsupported value"] = invalidNamespacePlacementInheritance
+
+	invalidClusterRateLimitQPS := testcommon.ValidKubeFedConfig()
+	invalidClusterRateLimitQPS.Spec.SyncController.ClusterRateLimit = &v1beta1.PropagationRateLimit{QPS: zeroIntPtr}
+	errorCases["spec.syncController.clusterRateLimit.qps: Invalid value"] = invalidClusterRateLimitQPS
+
+	invalidShardingMode := testcommon.ValidKubeFedConfig()
+	invalidShardingMode.Spec.SyncController.Sharding = &v1beta1.SyncSharding{Mode: "Cluster"}
+	errorCases["spec.syncController.sharding.mode: Unsupported value"] = invalidShardingMode
+
+	invalidShardingShards := testcommon.ValidKubeFedConfig()
+	invalidShardingShards.Spec.SyncController.Sharding = &v1beta1.SyncSharding{Mode: v1beta1.ShardingModeResource, Shards: zeroIntPtr}
+	errorCases["spec.syncController.sharding.shards: Invalid value"] = invalidShardingShards
+
 	invalidStatusControllerNil := testcommon.ValidKubeFedConfig()
 	invalidStatusControllerNil.Spec.StatusController = nil
 	errorCases["spec.statusController: Required value"] = invalidStatusControllerNil
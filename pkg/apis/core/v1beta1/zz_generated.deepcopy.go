@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -70,6 +71,21 @@ func (in *ClusterCondition) DeepCopy() *ClusterCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterComponent) DeepCopyInto(out *ClusterComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponent.
+func (in *ClusterComponent) DeepCopy() *ClusterComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterHealthCheckConfig) DeepCopyInto(out *ClusterHealthCheckConfig) {
 	*out = *in
@@ -93,6 +109,21 @@ func (in *ClusterHealthCheckConfig) DeepCopyInto(out *ClusterHealthCheckConfig)
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(ClusterHealthCheckMode)
+		**out = **in
+	}
+	if in.MinReadyNodesPercent != nil {
+		in, out := &in.MinReadyNodesPercent, &out.MinReadyNodesPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CriticalComponents != nil {
+		in, out := &in.CriticalComponents, &out.CriticalComponents
+		*out = make([]ClusterComponent, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHealthCheckConfig.
@@ -224,6 +255,26 @@ func (in *FederatedTypeConfigSpec) DeepCopyInto(out *FederatedTypeConfigSpec) {
 		*out = new(StatusCollectionMode)
 		**out = **in
 	}
+	if in.ApplyStrategy != nil {
+		in, out := &in.ApplyStrategy, &out.ApplyStrategy
+		*out = new(ApplyStrategy)
+		**out = **in
+	}
+	if in.RetainFields != nil {
+		in, out := &in.RetainFields, &out.RetainFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CacheMode != nil {
+		in, out := &in.CacheMode, &out.CacheMode
+		*out = new(CacheMode)
+		**out = **in
+	}
+	if in.SyncPriorities != nil {
+		in, out := &in.SyncPriorities, &out.SyncPriorities
+		*out = new(SyncPriorities)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedTypeConfigSpec.
@@ -329,6 +380,18 @@ func (in *KubeFedClusterSpec) DeepCopyInto(out *KubeFedClusterSpec) {
 		*out = make([]TLSValidation, len(*in))
 		copy(*out, *in)
 	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PropagationRateLimit != nil {
+		in, out := &in.PropagationRateLimit, &out.PropagationRateLimit
+		*out = new(PropagationRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeFedClusterSpec.
@@ -526,6 +589,36 @@ func (in *LocalSecretReference) DeepCopy() *LocalSecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropagationRateLimit) DeepCopyInto(out *PropagationRateLimit) {
+	*out = *in
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxInflight != nil {
+		in, out := &in.MaxInflight, &out.MaxInflight
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagationRateLimit.
+func (in *PropagationRateLimit) DeepCopy() *PropagationRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(PropagationRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusControllerConfig) DeepCopyInto(out *StatusControllerConfig) {
 	*out = *in
@@ -559,6 +652,21 @@ func (in *SyncControllerConfig) DeepCopyInto(out *SyncControllerConfig) {
 		*out = new(ResourceAdoption)
 		**out = **in
 	}
+	if in.NamespacePlacementInheritance != nil {
+		in, out := &in.NamespacePlacementInheritance, &out.NamespacePlacementInheritance
+		*out = new(NamespacePlacementInheritance)
+		**out = **in
+	}
+	if in.ClusterRateLimit != nil {
+		in, out := &in.ClusterRateLimit, &out.ClusterRateLimit
+		*out = new(PropagationRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = new(SyncSharding)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncControllerConfig.
@@ -570,3 +678,53 @@ func (in *SyncControllerConfig) DeepCopy() *SyncControllerConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncPriorities) DeepCopyInto(out *SyncPriorities) {
+	*out = *in
+	if in.UserChange != nil {
+		in, out := &in.UserChange, &out.UserChange
+		*out = new(SyncPriority)
+		**out = **in
+	}
+	if in.ClusterSync != nil {
+		in, out := &in.ClusterSync, &out.ClusterSync
+		*out = new(SyncPriority)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(SyncPriority)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncPriorities.
+func (in *SyncPriorities) DeepCopy() *SyncPriorities {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncPriorities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSharding) DeepCopyInto(out *SyncSharding) {
+	*out = *in
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSharding.
+func (in *SyncSharding) DeepCopy() *SyncSharding {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSharding)
+	in.DeepCopyInto(out)
+	return out
+}
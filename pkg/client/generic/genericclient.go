@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,6 +35,7 @@ type Client interface {
 	List(ctx context.Context, obj runtimeclient.ObjectList, namespace string, opts ...runtimeclient.ListOption) error
 	UpdateStatus(ctx context.Context, obj runtimeclient.Object) error
 	Patch(ctx context.Context, obj runtimeclient.Object, patch runtimeclient.Patch, opts ...runtimeclient.PatchOption) error
+	Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...runtimeclient.ApplyOption) error
 }
 
 type genericClient struct {
@@ -93,3 +95,7 @@ func (c *genericClient) UpdateStatus(ctx context.Context, obj runtimeclient.Obje
 func (c *genericClient) Patch(ctx context.Context, obj runtimeclient.Object, patch runtimeclient.Patch, opts ...runtimeclient.PatchOption) error {
 	return c.client.Patch(ctx, obj, patch, opts...)
 }
+
+func (c *genericClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...runtimeclient.ApplyOption) error {
+	return c.client.Apply(ctx, obj, opts...)
+}
@@ -52,7 +52,11 @@ type Controller struct {
 
 	// Map of running sync controllers keyed by qualified target type
 	stopChannels map[string]chan struct{}
-	lock         sync.RWMutex
+	// Generations of the type configs the running sync controllers
+	// were started with.  The status of a type config is not relied on
+	// since it may be updated by another replica when sharded.
+	generations map[string]int64
+	lock        sync.RWMutex
 
 	// Store for the FederatedTypeConfig objects
 	store cache.Store
@@ -91,6 +95,7 @@ func newController(config *utils.ControllerConfig) (*Controller, error) {
 		controllerConfig: config,
 		client:           genericClient,
 		stopChannels:     make(map[string]chan struct{}),
+		generations:      make(map[string]int64),
 	}
 
 	c.worker = utils.NewReconcileWorker("federatedtypeconfig", c.reconcile, utils.WorkerOptions{})
@@ -124,9 +129,18 @@ func (c *Controller) Run(stopChan <-chan struct{}) {
 
 	c.worker.Run(stopChan)
 
+	// Reconcile all type configs when the shards held by this replica
+	// change so that controllers are started or stopped accordingly.
+	removeListener := c.controllerConfig.Shards.AddListener(func() {
+		for _, obj := range c.store.List() {
+			c.worker.EnqueueObject(obj.(runtimeclient.Object))
+		}
+	})
+
 	// Ensure all goroutines are cleaned up when the stop channel closes
 	go func() {
 		<-stopChan
+		removeListener()
 		c.shutDown()
 	}()
 }
@@ -150,6 +164,15 @@ func (c *Controller) reconcile(qualifiedName utils.QualifiedName) utils.Reconcil
 	// TODO(marun) Perform this defaulting in a webhook
 	corev1b1.SetFederatedTypeConfigDefaults(typeConfig)
 
+	shards := c.controllerConfig.Shards
+	if !shards.OwnsTypeConfig(typeConfig.Name) {
+		c.stopControllers(typeConfig.Name)
+		return utils.StatusAllOK
+	}
+	// Every replica runs the controllers of a type config when sharding
+	// by resource, but only one updates its status.
+	updateStatus := shards.Owns(typeConfig.Name)
+
 	syncEnabled := typeConfig.GetPropagationEnabled()
 	// NOTE (Hector): RawResourceStatusCollection is a new feature and is
 	// Disabled by default. When RawResourceStatusCollection is enabled,
@@ -174,6 +197,9 @@ func (c *Controller) reconcile(qualifiedName utils.QualifiedName) utils.Reconcil
 			typeConfig.Status.StatusController = new(corev1b1.ControllerStatus)
 		}
 		*typeConfig.Status.StatusController = corev1b1.ControllerStatusNotRunning
+		if !updateStatus {
+			return utils.StatusAllOK
+		}
 		err = c.client.UpdateStatus(context.TODO(), typeConfig)
 		if err != nil {
 			runtime.HandleError(errors.Wrapf(err, "Could not update status fields of the CRD: %q", key))
@@ -242,8 +268,8 @@ func (c *Controller) reconcile(qualifiedName utils.QualifiedName) utils.Reconcil
 		c.stopController(statusKey, statusStopChan)
 	}
 
-	if !startNewSyncController && !stopSyncController &&
-		typeConfig.Status.ObservedGeneration != typeConfig.Generation {
+	if !startNewSyncController && !stopSyncController && syncRunning &&
+		c.getGeneration(typeConfig.Name) != typeConfig.Generation {
 		if err = c.refreshSyncController(c.ctx, c.immediate, typeConfig); err != nil {
 			runtime.HandleError(err)
 			return utils.StatusError
@@ -268,6 +294,9 @@ func (c *Controller) reconcile(qualifiedName utils.QualifiedName) utils.Reconcil
 	} else {
 		*typeConfig.Status.StatusController = corev1b1.ControllerStatusNotRunning
 	}
+	if !updateStatus {
+		return utils.StatusAllOK
+	}
 	err = c.client.UpdateStatus(context.TODO(), typeConfig)
 	if err != nil {
 		runtime.HandleError(errors.Wrapf(err, "Could not update status fields of the CRD: %q", key))
@@ -307,6 +336,22 @@ func (c *Controller) getStopChannel(name string) (chan struct{}, bool) {
 	return stopChan, ok
 }
 
+func (c *Controller) getGeneration(name string) int64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.generations[name]
+}
+
+// stopControllers stops the sync and status controllers of the named
+// type config if they are running.
+func (c *Controller) stopControllers(name string) {
+	for _, key := range []string{name, name + "/status"} {
+		if stopChan, ok := c.getStopChannel(key); ok {
+			c.stopController(key, stopChan)
+		}
+	}
+}
+
 func (c *Controller) startSyncController(ctx context.Context, immediate bool, tc *corev1b1.FederatedTypeConfig) error {
 	// TODO(marun) Consider using a shared informer for federated
 	// namespace that can be shared between all controllers of a
@@ -340,6 +385,7 @@ func (c *Controller) startSyncController(ctx context.Context, immediate bool, tc
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	c.stopChannels[ftc.Name] = stopChan
+	c.generations[ftc.Name] = ftc.Generation
 	return nil
 }
 
@@ -365,6 +411,7 @@ func (c *Controller) stopController(key string, stopChan chan struct{}) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	delete(c.stopChannels, key)
+	delete(c.generations, key)
 }
 
 func (c *Controller) refreshSyncController(ctx context.Context, immediate bool, tc *corev1b1.FederatedTypeConfig) error {
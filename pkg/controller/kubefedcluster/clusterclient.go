@@ -64,6 +64,9 @@ const (
 type ClusterClient struct {
 	kubeClient  *kubeclientset.Clientset
 	clusterName string
+	// watchConfig is the configuration of the cluster without the
+	// health check timeout, which would otherwise end watches.
+	watchConfig *restclient.Config
 }
 
 // NewClusterClientSet returns a ClusterClient for the given KubeFedCluster.
@@ -75,6 +78,7 @@ func NewClusterClientSet(c *fedv1b1.KubeFedCluster, client generic.Client, fedNa
 	if err != nil {
 		return &clusterClientSet, err
 	}
+	clusterClientSet.watchConfig = restclient.AddUserAgent(restclient.CopyConfig(clusterConfig), UserAgentName)
 	clusterConfig.Timeout = timeout
 	clusterClientSet.kubeClient, err = kubeclientset.NewForConfig(restclient.AddUserAgent(clusterConfig, UserAgentName))
 	return &clusterClientSet, err
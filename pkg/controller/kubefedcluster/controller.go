@@ -55,6 +55,10 @@ type ClusterData struct {
 
 	// cachedObj holds the last observer object from apiserver
 	cachedObj *fedv1b1.KubeFedCluster
+
+	// heartbeats watches the Leases of the nodes and critical
+	// components of the cluster when the heartbeat mode is enabled.
+	heartbeats *heartbeatMonitor
 }
 
 // ClusterController is responsible for maintaining the health status of each
@@ -168,6 +172,9 @@ func (cc *ClusterController) delFromClusterSet(obj *fedv1b1.KubeFedCluster) {
 	cc.mu.Lock()
 	defer cc.mu.Unlock()
 	klog.V(1).Infof("ClusterController observed a cluster deletion: %v", obj.Name)
+	if clusterData := cc.clusterDataMap[obj.Name]; clusterData != nil && clusterData.heartbeats != nil {
+		clusterData.heartbeats.Stop()
+	}
 	delete(cc.clusterDataMap, obj.Name)
 }
 
@@ -188,7 +195,20 @@ func (cc *ClusterController) addToClusterSet(obj *fedv1b1.KubeFedCluster) {
 		cc.RecordError(obj, "MalformedClusterConfig", errors.Wrap(err, "The configuration for this cluster may be malformed"))
 		klog.Errorf("The configuration for cluster %q may be malformed: %v", obj.Name, err)
 	}
-	cc.clusterDataMap[obj.Name] = &ClusterData{clusterKubeClient: restClient, cachedObj: obj.DeepCopy()}
+	clusterData = &ClusterData{clusterKubeClient: restClient, cachedObj: obj.DeepCopy()}
+	if cc.clusterHealthCheckConfig.Heartbeat && restClient.kubeClient != nil {
+		watchClient, err := kubeclient.NewForConfig(restClient.watchConfig)
+		if err != nil {
+			klog.Errorf("Failed to create client to watch the leases of cluster %q: %v", obj.Name, err)
+		} else {
+			clusterData.heartbeats = newHeartbeatMonitor(watchClient, cc.clusterHealthCheckConfig.CriticalComponents)
+			clusterData.heartbeats.Start()
+		}
+	}
+	if previous := cc.clusterDataMap[obj.Name]; previous != nil && previous.heartbeats != nil {
+		previous.heartbeats.Stop()
+	}
+	cc.clusterDataMap[obj.Name] = clusterData
 }
 
 // Run begins watching and syncing.
@@ -201,6 +221,17 @@ func (cc *ClusterController) Run(stopChan <-chan struct{}) {
 			klog.Errorf("Error monitoring cluster status: %v", err)
 		}
 	}, cc.clusterHealthCheckConfig.Period, stopChan)
+
+	go func() {
+		<-stopChan
+		cc.mu.Lock()
+		defer cc.mu.Unlock()
+		for _, clusterData := range cc.clusterDataMap {
+			if clusterData.heartbeats != nil {
+				clusterData.heartbeats.Stop()
+			}
+		}
+	}()
 }
 
 // updateClusterStatus checks cluster health and updates status of all KubeFedClusters
@@ -250,6 +281,10 @@ func (cc *ClusterController) updateIndividualClusterStatus(cluster *fedv1b1.Kube
 	}
 
 	currentClusterStatus = thresholdAdjustedClusterStatus(currentClusterStatus, storedData, cc.clusterHealthCheckConfig)
+	if storedData.heartbeats != nil {
+		heartbeatConditions := storedData.heartbeats.conditions(time.Now(), cc.clusterHealthCheckConfig.MinReadyNodesPercent)
+		setHeartbeatConditions(currentClusterStatus, storedData.clusterStatus, heartbeatConditions)
+	}
 
 	storedData.clusterStatus = currentClusterStatus
 	cluster.Status = *currentClusterStatus
@@ -0,0 +1,316 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefedcluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coordinationinformers "k8s.io/client-go/informers/coordination/v1"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	fedcommon "sigs.k8s.io/kubefed/pkg/apis/core/common"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+const (
+	// Reasons and messages of the heartbeat conditions of KubeFedClusterStatus
+	NodesHealthyReason        = "NodesHealthy"
+	NodesUnhealthyReason      = "NodesUnhealthy"
+	NodesHeartbeatMsg         = "%d of %d nodes renewed their lease"
+	NoNodeLeasesReason        = "NoNodeLeases"
+	NoNodeLeasesMsg           = "no node leases found"
+	ComponentsHealthyReason   = "ComponentsHealthy"
+	ComponentsHealthyMsg      = "all %d critical components renewed their lease"
+	ComponentsUnhealthyReason = "ComponentsUnhealthy"
+	HeartbeatsNotSyncedReason = "HeartbeatsNotSynced"
+	HeartbeatsNotSyncedMsg    = "leases of the cluster have not been retrieved yet"
+
+	leaseNotFoundMsg   = "lease not found"
+	leaseNotRenewedMsg = "lease not renewed since %s"
+
+	// The duration of a Lease that does not specify one, matching the
+	// duration of node Leases.
+	defaultLeaseDuration = 40 * time.Second
+)
+
+// heartbeatMonitor watches the Leases renewed by the nodes and critical
+// components of a member cluster.  The time a Lease is renewed is
+// taken from the host cluster's clock when the renewal is observed, so
+// that the health of a member cluster is not affected by clock skew.
+type heartbeatMonitor struct {
+	components []fedv1b1.ClusterComponent
+	informers  []cache.SharedIndexInformer
+
+	lock sync.Mutex
+	// renewals of Leases keyed by namespace/name.
+	renewals map[string]heartbeat
+	// forbiddenErrors of listing the Leases of a namespace keyed by
+	// namespace.
+	forbiddenErrors map[string]error
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+}
+
+type heartbeat struct {
+	renewTime     *metav1.MicroTime
+	observed      time.Time
+	leaseDuration time.Duration
+}
+
+func newHeartbeatMonitor(client kubeclientset.Interface, components []fedv1b1.ClusterComponent) *heartbeatMonitor {
+	m := &heartbeatMonitor{
+		components:      components,
+		renewals:        make(map[string]heartbeat),
+		forbiddenErrors: make(map[string]error),
+		stopChan:        make(chan struct{}),
+	}
+	namespaces := sets.New(corev1.NamespaceNodeLease)
+	for _, component := range components {
+		namespaces.Insert(component.Namespace)
+	}
+	for _, namespace := range sets.List(namespaces) {
+		informer := coordinationinformers.NewLeaseInformer(client, namespace, utils.NoResyncPeriod, cache.Indexers{})
+		_, _ = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				m.observe(obj)
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				m.observe(obj)
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if lease, ok := obj.(*coordinationv1.Lease); ok {
+					m.lock.Lock()
+					defer m.lock.Unlock()
+					delete(m.renewals, leaseKey(lease.Namespace, lease.Name))
+				}
+			},
+		})
+		// Leases cannot be retrieved if the service account of the
+		// cluster is not allowed to list them, in which case the
+		// error is reported instead of waiting for the informer.
+		_ = informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+			if apierrors.IsForbidden(err) {
+				m.lock.Lock()
+				m.forbiddenErrors[namespace] = err
+				m.lock.Unlock()
+			}
+			cache.DefaultWatchErrorHandler(ctx, r, err)
+		})
+		m.informers = append(m.informers, informer)
+	}
+	return m
+}
+
+func (m *heartbeatMonitor) Start() {
+	for _, informer := range m.informers {
+		go informer.Run(m.stopChan)
+	}
+}
+
+func (m *heartbeatMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopChan)
+	})
+}
+
+func (m *heartbeatMonitor) HasSynced() bool {
+	for _, informer := range m.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// observe records the renewal of a Lease.  A Lease seen for the first
+// time is considered renewed at its renew time, or now if its renew
+// time is in the future according to the host cluster's clock.
+func (m *heartbeatMonitor) observe(obj interface{}) {
+	lease, ok := obj.(*coordinationv1.Lease)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	key := leaseKey(lease.Namespace, lease.Name)
+	leaseDuration := defaultLeaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		leaseDuration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	previous, seen := m.renewals[key]
+	current := heartbeat{renewTime: lease.Spec.RenewTime, leaseDuration: leaseDuration}
+	switch {
+	case !seen:
+		if lease.Spec.RenewTime != nil {
+			current.observed = lease.Spec.RenewTime.Time
+			if current.observed.After(now) {
+				current.observed = now
+			}
+		}
+	case !previous.renewTime.Equal(lease.Spec.RenewTime):
+		current.observed = now
+	default:
+		current.observed = previous.observed
+	}
+	m.renewals[key] = current
+}
+
+// conditions returns the NodesHealthy and ComponentsHealthy conditions
+// of the cluster as of the given time.
+func (m *heartbeatMonitor) conditions(now time.Time, minReadyNodesPercent int64) []fedv1b1.ClusterCondition {
+	probeTime := metav1.NewTime(now)
+	if !m.HasSynced() {
+		msg := m.notSyncedMessage()
+		conditions := []fedv1b1.ClusterCondition{
+			newCondition(fedcommon.ClusterNodesHealthy, corev1.ConditionUnknown, HeartbeatsNotSyncedReason, msg, probeTime),
+		}
+		if len(m.components) > 0 {
+			conditions = append(conditions, newCondition(fedcommon.ClusterComponentsHealthy, corev1.ConditionUnknown,
+				HeartbeatsNotSyncedReason, msg, probeTime))
+		}
+		return conditions
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var nodes, readyNodes int64
+	for key, renewal := range m.renewals {
+		if !strings.HasPrefix(key, corev1.NamespaceNodeLease+"/") {
+			continue
+		}
+		nodes++
+		if renewal.alive(now) {
+			readyNodes++
+		}
+	}
+	var conditions []fedv1b1.ClusterCondition
+	nodesMsg := fmt.Sprintf(NodesHeartbeatMsg, readyNodes, nodes)
+	switch {
+	case nodes == 0:
+		conditions = append(conditions, newCondition(fedcommon.ClusterNodesHealthy, corev1.ConditionUnknown, NoNodeLeasesReason, NoNodeLeasesMsg, probeTime))
+	case readyNodes*100 >= minReadyNodesPercent*nodes:
+		conditions = append(conditions, newCondition(fedcommon.ClusterNodesHealthy, corev1.ConditionTrue, NodesHealthyReason, nodesMsg, probeTime))
+	default:
+		conditions = append(conditions, newCondition(fedcommon.ClusterNodesHealthy, corev1.ConditionFalse, NodesUnhealthyReason, nodesMsg, probeTime))
+	}
+
+	if len(m.components) == 0 {
+		return conditions
+	}
+	var unhealthy []string
+	for _, component := range m.components {
+		key := leaseKey(component.Namespace, component.Name)
+		renewal, ok := m.renewals[key]
+		switch {
+		case !ok:
+			unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", key, leaseNotFoundMsg))
+		case !renewal.alive(now):
+			unhealthy = append(unhealthy, fmt.Sprintf("%s: "+leaseNotRenewedMsg, key, renewal.observed.UTC().Format(time.RFC3339)))
+		}
+	}
+	if len(unhealthy) == 0 {
+		msg := fmt.Sprintf(ComponentsHealthyMsg, len(m.components))
+		return append(conditions, newCondition(fedcommon.ClusterComponentsHealthy, corev1.ConditionTrue, ComponentsHealthyReason, msg, probeTime))
+	}
+	sort.Strings(unhealthy)
+	return append(conditions, newCondition(fedcommon.ClusterComponentsHealthy, corev1.ConditionFalse,
+		ComponentsUnhealthyReason, strings.Join(unhealthy, "; "), probeTime))
+}
+
+// notSyncedMessage returns the errors of Leases that are forbidden to
+// be listed, or a message that Leases have not been retrieved yet.
+func (m *heartbeatMonitor) notSyncedMessage() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if len(m.forbiddenErrors) == 0 {
+		return HeartbeatsNotSyncedMsg
+	}
+	var msgs []string
+	for _, err := range m.forbiddenErrors {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	return strings.Join(msgs, "; ")
+}
+
+func (h heartbeat) alive(now time.Time) bool {
+	return !h.observed.IsZero() && !h.observed.Add(h.leaseDuration).Before(now)
+}
+
+func leaseKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func newCondition(conditionType fedcommon.ClusterConditionType, status corev1.ConditionStatus,
+	reason, message string, probeTime metav1.Time) fedv1b1.ClusterCondition {
+	return fedv1b1.ClusterCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             &reason,
+		Message:            &message,
+		LastProbeTime:      probeTime,
+		LastTransitionTime: &probeTime,
+	}
+}
+
+// setHeartbeatConditions replaces the heartbeat conditions of the given
+// status, preserving the transition time of a condition whose status is
+// unchanged from the previous status.
+func setHeartbeatConditions(clusterStatus, previous *fedv1b1.KubeFedClusterStatus, heartbeatConditions []fedv1b1.ClusterCondition) {
+	conditions := make([]fedv1b1.ClusterCondition, 0, len(clusterStatus.Conditions)+len(heartbeatConditions))
+	for _, condition := range clusterStatus.Conditions {
+		if !isHeartbeatCondition(condition.Type) {
+			conditions = append(conditions, condition)
+		}
+	}
+	for _, condition := range heartbeatConditions {
+		if previous != nil {
+			for _, previousCondition := range previous.Conditions {
+				if previousCondition.Type == condition.Type && previousCondition.Status == condition.Status &&
+					previousCondition.LastTransitionTime != nil {
+					transitionTime := *previousCondition.LastTransitionTime
+					condition.LastTransitionTime = &transitionTime
+				}
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+	clusterStatus.Conditions = conditions
+}
+
+func isHeartbeatCondition(conditionType fedcommon.ClusterConditionType) bool {
+	return conditionType == fedcommon.ClusterNodesHealthy || conditionType == fedcommon.ClusterComponentsHealthy
+}
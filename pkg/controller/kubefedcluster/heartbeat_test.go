@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefedcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/common"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestHeartbeatConditions(t *testing.T) {
+	now := time.Now()
+	fresh := now.Add(-5 * time.Second)
+	stale := now.Add(-5 * time.Minute)
+	scheduler := fedv1b1.ClusterComponent{Namespace: metav1.NamespaceSystem, Name: "kube-scheduler"}
+
+	testCases := map[string]struct {
+		nodeRenewals         []time.Time
+		componentRenewals    map[string]time.Time
+		components           []fedv1b1.ClusterComponent
+		minReadyNodesPercent int64
+		expected             map[common.ClusterConditionType]corev1.ConditionStatus
+	}{
+		"healthy nodes and components": {
+			nodeRenewals:         []time.Time{fresh, fresh, fresh, fresh},
+			componentRenewals:    map[string]time.Time{"kube-scheduler": fresh},
+			components:           []fedv1b1.ClusterComponent{scheduler},
+			minReadyNodesPercent: 100,
+			expected: map[common.ClusterConditionType]corev1.ConditionStatus{
+				common.ClusterNodesHealthy:      corev1.ConditionTrue,
+				common.ClusterComponentsHealthy: corev1.ConditionTrue,
+			},
+		},
+		"ready nodes below the minimum percentage": {
+			nodeRenewals:         []time.Time{fresh, fresh, fresh, stale},
+			minReadyNodesPercent: 90,
+			expected: map[common.ClusterConditionType]corev1.ConditionStatus{
+				common.ClusterNodesHealthy: corev1.ConditionFalse,
+			},
+		},
+		"ready nodes at the minimum percentage": {
+			nodeRenewals:         []time.Time{fresh, fresh, fresh, stale},
+			minReadyNodesPercent: 75,
+			expected: map[common.ClusterConditionType]corev1.ConditionStatus{
+				common.ClusterNodesHealthy: corev1.ConditionTrue,
+			},
+		},
+		"no node leases": {
+			minReadyNodesPercent: 90,
+			expected: map[common.ClusterConditionType]corev1.ConditionStatus{
+				common.ClusterNodesHealthy: corev1.ConditionUnknown,
+			},
+		},
+		"component lease not renewed": {
+			nodeRenewals:         []time.Time{fresh},
+			componentRenewals:    map[string]time.Time{"kube-scheduler": stale},
+			components:           []fedv1b1.ClusterComponent{scheduler},
+			minReadyNodesPercent: 90,
+			expected: map[common.ClusterConditionType]corev1.ConditionStatus{
+				common.ClusterNodesHealthy:      corev1.ConditionTrue,
+				common.ClusterComponentsHealthy: corev1.ConditionFalse,
+			},
+		},
+		"component lease not found": {
+			nodeRenewals:         []time.Time{fresh},
+			components:           []fedv1b1.ClusterComponent{scheduler},
+			minReadyNodesPercent: 90,
+			expected: map[common.ClusterConditionType]corev1.ConditionStatus{
+				common.ClusterNodesHealthy:      corev1.ConditionTrue,
+				common.ClusterComponentsHealthy: corev1.ConditionFalse,
+			},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			var objects []runtime.Object
+			for i, renewTime := range tc.nodeRenewals {
+				objects = append(objects, newLease(corev1.NamespaceNodeLease, fmt.Sprintf("node%d", i), renewTime, 40))
+			}
+			for name, renewTime := range tc.componentRenewals {
+				objects = append(objects, newLease(metav1.NamespaceSystem, name, renewTime, 15))
+			}
+			monitor := newHeartbeatMonitor(fake.NewClientset(objects...), tc.components)
+			monitor.Start()
+			defer monitor.Stop()
+			err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 10*time.Second, true, func(_ context.Context) (bool, error) {
+				return monitor.HasSynced(), nil
+			})
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+
+			conditions := monitor.conditions(now, tc.minReadyNodesPercent)
+			if len(conditions) != len(tc.expected) {
+				t.Fatalf("Expected %d conditions, got %v", len(tc.expected), conditions)
+			}
+			for _, condition := range conditions {
+				if expected := tc.expected[condition.Type]; condition.Status != expected {
+					t.Fatalf("Expected %s to be %s, got %s: %s", condition.Type, expected, condition.Status, *condition.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestHeartbeatConditionsForbidden(t *testing.T) {
+	client := fake.NewClientset()
+	client.PrependReactor("list", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(coordinationv1.Resource("leases"), "", fmt.Errorf("not allowed"))
+	})
+	monitor := newHeartbeatMonitor(client, nil)
+	monitor.Start()
+	defer monitor.Stop()
+
+	var conditions []fedv1b1.ClusterCondition
+	err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 10*time.Second, true, func(_ context.Context) (bool, error) {
+		conditions = monitor.conditions(time.Now(), 100)
+		return *conditions[0].Message != HeartbeatsNotSyncedMsg, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected the forbidden error to be reported, got %q", *conditions[0].Message)
+	}
+	if conditions[0].Status != corev1.ConditionUnknown || !strings.Contains(*conditions[0].Message, "forbidden") {
+		t.Fatalf("Expected %s to be %s with the forbidden error, got %s: %s", conditions[0].Type,
+			corev1.ConditionUnknown, conditions[0].Status, *conditions[0].Message)
+	}
+}
+
+func TestSetHeartbeatConditions(t *testing.T) {
+	t1 := metav1.Now()
+	t2 := metav1.Time{Time: t1.Add(10 * time.Second)}
+	previous := clusterStatus(corev1.ConditionTrue, t1, t1)
+	previous.Conditions = append(previous.Conditions,
+		newCondition(common.ClusterNodesHealthy, corev1.ConditionTrue, NodesHealthyReason, "", t1),
+		newCondition(common.ClusterComponentsHealthy, corev1.ConditionTrue, ComponentsHealthyReason, "", t1))
+
+	// The heartbeat conditions of the previous status are kept by the
+	// threshold adjustment, and must be replaced rather than repeated.
+	current := previous.DeepCopy()
+	setHeartbeatConditions(current, previous, []fedv1b1.ClusterCondition{
+		newCondition(common.ClusterNodesHealthy, corev1.ConditionTrue, NodesHealthyReason, "", t2),
+		newCondition(common.ClusterComponentsHealthy, corev1.ConditionFalse, ComponentsUnhealthyReason, "", t2),
+	})
+
+	if len(current.Conditions) != 3 {
+		t.Fatalf("Expected 3 conditions, got %d", len(current.Conditions))
+	}
+	for _, condition := range current.Conditions {
+		switch condition.Type {
+		case common.ClusterNodesHealthy:
+			if !condition.LastTransitionTime.Equal(&t1) || !condition.LastProbeTime.Equal(&t2) {
+				t.Fatalf("Expected the transition time of an unchanged condition to be preserved")
+			}
+		case common.ClusterComponentsHealthy:
+			if !condition.LastTransitionTime.Equal(&t2) {
+				t.Fatalf("Expected the transition time of a changed condition to be updated")
+			}
+		}
+	}
+}
+
+func newLease(namespace, name string, renewTime time.Time, durationSeconds int32) *coordinationv1.Lease {
+	renewMicroTime := metav1.NewMicroTime(renewTime)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: coordinationv1.LeaseSpec{
+			RenewTime:            &renewMicroTime,
+			LeaseDurationSeconds: &durationSeconds,
+		},
+	}
+}
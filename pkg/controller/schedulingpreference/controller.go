@@ -189,7 +189,7 @@ func (s *SchedulingPreferenceController) reconcileOnClusterChange() {
 	}
 	for _, obj := range s.store.List() {
 		qualifiedName := utils.NewQualifiedName(obj.(runtimeclient.Object))
-		s.worker.EnqueueWithDelay(qualifiedName, s.smallDelay)
+		s.worker.EnqueueWithClass(qualifiedName, utils.ClusterSyncWork, s.smallDelay)
 	}
 }
 
@@ -40,6 +40,7 @@ import (
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
 	"sigs.k8s.io/kubefed/pkg/controller/utils"
+	"sigs.k8s.io/kubefed/pkg/controller/utils/sharding"
 	"sigs.k8s.io/kubefed/pkg/metrics"
 )
 
@@ -84,6 +85,9 @@ type KubeFedStatusController struct {
 
 	ctx       context.Context
 	immediate bool
+
+	// The shards of federated resources reconciled by this replica.
+	shards *sharding.Manager
 }
 
 // StartKubeFedStatusController starts a new status controller for a type config
@@ -131,6 +135,7 @@ func newKubeFedStatusController(controllerConfig *utils.ControllerConfig, typeCo
 		client:                  client,
 		statusClient:            statusClient,
 		fedNamespace:            controllerConfig.KubeFedNamespace,
+		shards:                  controllerConfig.Shards,
 	}
 
 	s.worker = utils.NewReconcileWorker(strings.ToLower(statusAPIResource.Kind), s.reconcile, utils.WorkerOptions{
@@ -157,6 +162,7 @@ func newKubeFedStatusController(controllerConfig *utils.ControllerConfig, typeCo
 		controllerConfig,
 		client,
 		&targetAPIResource,
+		utils.CacheTransformForType(typeConfig),
 		func(obj runtimeclient.Object) {
 			qualifiedName := utils.NewQualifiedName(obj)
 			s.worker.EnqueueForRetry(qualifiedName)
@@ -198,9 +204,16 @@ func (s *KubeFedStatusController) Run(stopChan <-chan struct{}) {
 
 	s.worker.Run(stopChan)
 
+	// Reconcile all the federated resources when the shards held by
+	// this replica change.
+	removeListener := s.shards.AddListener(func() {
+		s.clusterDeliverer.DeliverAt(allClustersKey, nil, time.Now())
+	})
+
 	// Ensure all goroutines are cleaned up when the stop channel closes
 	go func() {
 		<-stopChan
+		removeListener()
 		s.informer.Stop()
 		s.clusterDeliverer.Stop()
 	}()
@@ -248,11 +261,15 @@ func (s *KubeFedStatusController) reconcileOnClusterChange() {
 	}
 	for _, obj := range s.federatedStore.List() {
 		qualifiedName := utils.NewQualifiedName(obj.(runtimeclient.Object))
-		s.worker.EnqueueWithDelay(qualifiedName, s.smallDelay)
+		s.worker.EnqueueWithClass(qualifiedName, utils.ClusterSyncWork, s.smallDelay)
 	}
 }
 
 func (s *KubeFedStatusController) reconcile(qualifiedName utils.QualifiedName) utils.ReconciliationStatus {
+	if !s.shards.OwnsResource(qualifiedName.String()) {
+		return utils.StatusAllOK
+	}
+
 	if err := s.waitForSync(); err != nil {
 		klog.Fatalf("failed to wait for all data stores to sync: %v", err)
 	}
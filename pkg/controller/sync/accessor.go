@@ -28,6 +28,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1a1 "sigs.k8s.io/kubefed/pkg/apis/core/v1alpha1"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/version"
 	"sigs.k8s.io/kubefed/pkg/controller/utils"
@@ -40,6 +42,7 @@ type FederatedResourceAccessor interface {
 	HasSynced() bool
 	FederatedResource(qualifiedName utils.QualifiedName) (federatedResource FederatedResource, possibleOrphan bool, err error)
 	VisitFederatedResources(visitFunc func(obj interface{}))
+	RefreshVersions() error
 }
 
 type resourceAccessor struct {
@@ -48,6 +51,14 @@ type resourceAccessor struct {
 	targetIsNamespace bool
 	fedNamespace      string
 
+	// Whether resources without placement inherit the placement of
+	// their federated namespace by default.
+	inheritNamespacePlacement bool
+
+	// Returns the member clusters used to resolve overrides for
+	// cluster selectors.
+	getClusters func() ([]*fedv1b1.KubeFedCluster, error)
+
 	// The informer for the federated type.
 	federatedStore      cache.Store
 	federatedController cache.Controller
@@ -66,6 +77,12 @@ type resourceAccessor struct {
 	fedNamespaceStore      cache.Store
 	fedNamespaceController cache.Controller
 
+	// The informer used to source placement policies referenced by
+	// federated resources or their federated namespaces.  Will not be
+	// initialized if the PlacementPolicy API is not installed.
+	placementPolicyStore      cache.Store
+	placementPolicyController cache.Controller
+
 	// Manages propagated versions
 	versionManager *version.Manager
 
@@ -77,7 +94,7 @@ type resourceAccessor struct {
 	immediate bool
 }
 
-func NewFederatedResourceAccessor(ctx context.Context, immediate bool, controllerConfig *utils.ControllerConfig, typeConfig typeconfig.Interface, fedNamespaceAPIResource *metav1.APIResource, client genericclient.Client, enqueueObj func(runtimeclient.Object), eventRecorder record.EventRecorder) (FederatedResourceAccessor, error) {
+func NewFederatedResourceAccessor(ctx context.Context, immediate bool, controllerConfig *utils.ControllerConfig, typeConfig typeconfig.Interface, fedNamespaceAPIResource *metav1.APIResource, client genericclient.Client, enqueueObj func(runtimeclient.Object), eventRecorder record.EventRecorder, getClusters func() ([]*fedv1b1.KubeFedCluster, error)) (FederatedResourceAccessor, error) {
 	a := &resourceAccessor{
 		limitedScope:            controllerConfig.LimitedScope(),
 		typeConfig:              typeConfig,
@@ -85,6 +102,9 @@ func NewFederatedResourceAccessor(ctx context.Context, immediate bool, controlle
 		fedNamespace:            controllerConfig.KubeFedNamespace,
 		fedNamespaceAPIResource: fedNamespaceAPIResource,
 		eventRecorder:           eventRecorder,
+
+		inheritNamespacePlacement: controllerConfig.InheritNamespacePlacement,
+		getClusters:               getClusters,
 	}
 
 	targetNamespace := controllerConfig.TargetNamespace
@@ -135,6 +155,39 @@ func NewFederatedResourceAccessor(ctx context.Context, immediate bool, controlle
 		a.fedNamespaceStore, a.fedNamespaceController = utils.NewResourceInformer(fedNamespaceClient, targetNamespace, fedNamespaceAPIResource, fedNamespaceEnqueue)
 	}
 
+	placementPolicyEnqueue := func(policyObj runtimeclient.Object) {
+		// When a placement policy changes, every resource
+		// referencing it either directly or through its federated
+		// namespace needs to be reconciled.
+		policyName := utils.NewQualifiedName(policyObj)
+		namespaceReferences := false
+		if a.fedNamespaceStore != nil {
+			fedNamespaceName := utils.QualifiedName{Namespace: policyName.Namespace, Name: policyName.Namespace}
+			fedNamespace, err := utils.ObjFromCache(a.fedNamespaceStore, fedNamespaceAPIResource.Kind, fedNamespaceName.String())
+			namespaceReferences = err == nil && fedNamespace != nil && a.referencesPlacementPolicy(fedNamespace, policyName)
+		}
+		for _, rawObj := range a.federatedStore.List() {
+			obj := rawObj.(*unstructured.Unstructured)
+			if a.referencesPlacementPolicy(obj, policyName) || namespaceReferences && obj.GetNamespace() == policyName.Namespace {
+				enqueueObj(obj)
+			}
+		}
+	}
+	a.placementPolicyStore, a.placementPolicyController, err = utils.NewGenericInformer(
+		controllerConfig.KubeConfig,
+		targetNamespace,
+		&fedv1a1.PlacementPolicy{},
+		utils.NoResyncPeriod,
+		placementPolicyEnqueue,
+	)
+	if err != nil {
+		// Resources referencing a placement policy will report a
+		// placement failure rather than preventing the propagation
+		// of all resources of the type.
+		klog.Warningf("Placement policies will not be resolved for %s: %v", typeConfig.GetFederatedType().Kind, err)
+		a.placementPolicyStore, a.placementPolicyController = nil, nil
+	}
+
 	a.versionManager = version.NewVersionManager(ctx, immediate, client, typeConfig.GetFederatedNamespaced(), typeConfig.GetFederatedType().Kind, typeConfig.GetTargetType().Kind, targetNamespace)
 
 	return a, nil
@@ -149,6 +202,9 @@ func (a *resourceAccessor) Run(stopChan <-chan struct{}) {
 	if a.fedNamespaceController != nil {
 		go a.fedNamespaceController.Run(stopChan)
 	}
+	if a.placementPolicyController != nil {
+		go a.placementPolicyController.Run(stopChan)
+	}
 }
 
 func (a *resourceAccessor) HasSynced() bool {
@@ -169,6 +225,10 @@ func (a *resourceAccessor) HasSynced() bool {
 		klog.V(2).Infof("FederatedNamespace informer for %s not synced", kind)
 		return false
 	}
+	if a.placementPolicyController != nil && !a.placementPolicyController.HasSynced() {
+		klog.V(2).Infof("PlacementPolicy informer for %s not synced", kind)
+		return false
+	}
 	return true
 }
 
@@ -270,6 +330,11 @@ func (a *resourceAccessor) FederatedResource(eventSource utils.QualifiedName) (F
 		namespace:         namespace,
 		fedNamespace:      fedNamespace,
 		eventRecorder:     a.eventRecorder,
+
+		kubeFedNamespace:          a.fedNamespace,
+		placementPolicyStore:      a.placementPolicyStore,
+		inheritNamespacePlacement: a.inheritNamespacePlacement,
+		getClusters:               a.getClusters,
 	}, false, nil
 }
 
@@ -279,6 +344,22 @@ func (a *resourceAccessor) VisitFederatedResources(visitFunc func(obj interface{
 	}
 }
 
+// RefreshVersions retrieves the propagated versions of the federated
+// resources from the API again.
+func (a *resourceAccessor) RefreshVersions() error {
+	return a.versionManager.Refresh()
+}
+
+// referencesPlacementPolicy returns whether the given federated
+// object references the placement policy with the given name.
+func (a *resourceAccessor) referencesPlacementPolicy(obj *unstructured.Unstructured, policyName utils.QualifiedName) bool {
+	name, err := utils.GetPlacementRef(obj)
+	if err != nil || name == "" {
+		return false
+	}
+	return utils.PlacementPolicyName(obj, name, a.fedNamespace) == policyName
+}
+
 func (a *resourceAccessor) isSystemNamespace(namespace string) bool {
 	// TODO(font): Need a configurable or discoverable list of namespaces
 	// to not propagate beyond just the default system namespaces e.g.
@@ -36,6 +36,8 @@ import (
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
@@ -46,8 +48,10 @@ import (
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/history"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
 	"sigs.k8s.io/kubefed/pkg/controller/utils"
+	"sigs.k8s.io/kubefed/pkg/controller/utils/sharding"
 	"sigs.k8s.io/kubefed/pkg/metrics"
 )
 
@@ -105,6 +109,21 @@ type KubeFedSyncController struct {
 
 	// Flag to indicate whether to collect raw resource status information.
 	rawResourceStatusCollection bool
+
+	// The KubeFed system namespace, which contains the revisions of
+	// cluster-scoped federated resources.
+	kubeFedNamespace string
+
+	// Limits on the operations propagated to each member cluster,
+	// shared with the sync controllers of other federated types.
+	clusterLimiters *utils.ClusterLimiters
+
+	// The shards of federated resources reconciled by this replica.
+	shards *sharding.Manager
+
+	// Informer and lister of the revisions of federated resources.
+	revisionInformer cache.SharedIndexInformer
+	revisionLister   appslisters.ControllerRevisionLister
 }
 
 // StartKubeFedSyncController starts a new sync controller for a type config
@@ -146,13 +165,21 @@ func newKubeFedSyncController(ctx context.Context, immediate bool, controllerCon
 		skipAdoptingResources:       controllerConfig.SkipAdoptingResources,
 		limitedScope:                controllerConfig.LimitedScope(),
 		rawResourceStatusCollection: controllerConfig.RawResourceStatusCollection,
+		kubeFedNamespace:            controllerConfig.KubeFedNamespace,
+		clusterLimiters:             controllerConfig.ClusterLimiters,
+		shards:                      controllerConfig.Shards,
 	}
 
+	s.revisionInformer = history.NewInformer(kubeClient, controllerConfig.TargetNamespace, federatedTypeAPIResource.Kind)
+	s.revisionLister = appslisters.NewControllerRevisionLister(s.revisionInformer.GetIndexer())
+
+	priorities := utils.NewWorkPriorities(typeConfig.GetSyncPriorities())
 	s.worker = utils.NewReconcileWorker(strings.ToLower(federatedTypeAPIResource.Kind), s.reconcile, utils.WorkerOptions{
 		WorkerTiming: utils.WorkerTiming{
 			ClusterSyncDelay: s.clusterAvailableDelay,
 		},
 		MaxConcurrentReconciles: int(controllerConfig.MaxConcurrentSyncReconciles),
+		Priorities:              &priorities,
 	})
 
 	// Build deliverer for triggering cluster reconciliations.
@@ -166,6 +193,7 @@ func newKubeFedSyncController(ctx context.Context, immediate bool, controllerCon
 		controllerConfig,
 		client,
 		&targetAPIResource,
+		utils.CacheTransformForType(typeConfig),
 		func(obj runtimeclient.Object) {
 			qualifiedName := utils.NewQualifiedName(obj)
 			s.worker.EnqueueForRetry(qualifiedName)
@@ -185,7 +213,7 @@ func newKubeFedSyncController(ctx context.Context, immediate bool, controllerCon
 		return nil, err
 	}
 
-	s.fedAccessor, err = NewFederatedResourceAccessor(ctx, immediate, controllerConfig, typeConfig, fedNamespaceAPIResource, client, s.worker.EnqueueObject, recorder)
+	s.fedAccessor, err = NewFederatedResourceAccessor(ctx, immediate, controllerConfig, typeConfig, fedNamespaceAPIResource, client, s.worker.EnqueueObject, recorder, s.informer.GetClusters)
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +231,7 @@ func (s *KubeFedSyncController) minimizeLatency() {
 
 func (s *KubeFedSyncController) Run(stopChan <-chan struct{}) {
 	s.fedAccessor.Run(stopChan)
+	go s.revisionInformer.Run(stopChan)
 	s.informer.Start()
 	s.clusterDeliverer.StartWithHandler(func(_ *utils.DelayingDelivererItem) {
 		s.reconcileOnClusterChange()
@@ -210,9 +239,23 @@ func (s *KubeFedSyncController) Run(stopChan <-chan struct{}) {
 
 	s.worker.Run(stopChan)
 
+	// Reconcile all the federated resources when the shards held by
+	// this replica change.  The versions of resources in acquired
+	// shards may have been written by another replica, so they are
+	// retrieved again first without blocking the renewal of shards.
+	removeListener := s.shards.AddListener(func() {
+		go func() {
+			if err := s.fedAccessor.RefreshVersions(); err != nil {
+				runtime.HandleError(err)
+			}
+			s.clusterDeliverer.DeliverAt(allClustersKey, nil, time.Now())
+		}()
+	})
+
 	// Ensure all goroutines are cleaned up when the stop channel closes
 	go func() {
 		<-stopChan
+		removeListener()
 		s.informer.Stop()
 		s.clusterDeliverer.Stop()
 	}()
@@ -237,6 +280,10 @@ func (s *KubeFedSyncController) isSynced() bool {
 		// complete.go
 		return false
 	}
+	if !s.revisionInformer.HasSynced() {
+		klog.V(2).Info("Revisions not synced")
+		return false
+	}
 
 	// TODO(marun) set clusters as ready in the test fixture?
 	clusters, err := s.informer.GetReadyClusters()
@@ -258,11 +305,15 @@ func (s *KubeFedSyncController) reconcileOnClusterChange() {
 	}
 	s.fedAccessor.VisitFederatedResources(func(obj interface{}) {
 		qualifiedName := utils.NewQualifiedName(obj.(runtimeclient.Object))
-		s.worker.EnqueueWithDelay(qualifiedName, s.smallDelay)
+		s.worker.EnqueueWithClass(qualifiedName, utils.ClusterSyncWork, s.smallDelay)
 	})
 }
 
 func (s *KubeFedSyncController) reconcile(qualifiedName utils.QualifiedName) utils.ReconciliationStatus {
+	if !s.shards.OwnsResource(qualifiedName.String()) {
+		return utils.StatusAllOK
+	}
+
 	if err := s.waitForSync(); err != nil {
 		klog.Fatalf("failed to wait for all data stores to sync: %v", err)
 	}
@@ -286,11 +337,7 @@ func (s *KubeFedSyncController) reconcile(qualifiedName utils.QualifiedName) uti
 			runtime.HandleError(wrappedErr)
 			return utils.StatusError
 		}
-		clusterNames := sets.Set[string]{}
-		for _, cluster := range clusters {
-			clusterNames = clusterNames.Insert(cluster.Name)
-		}
-		err = s.removeManagedLabel(gvk, qualifiedName, clusterNames)
+		err = s.removeManagedLabel(gvk, qualifiedName, getClusterNames(clusters))
 		if err != nil {
 			wrappedErr := errors.Wrapf(err, "failed to remove the label %q from %s %q in member clusters", utils.ManagedByKubeFedLabelKey, gvk.Kind, qualifiedName)
 			runtime.HandleError(wrappedErr)
@@ -346,12 +393,63 @@ func (s *KubeFedSyncController) syncToClusters(fedResource FederatedResource) ut
 		return s.setFederatedStatus(fedResource, status.ComputePlacementFailed, nil, nil, enableRawResourceStatusCollection)
 	}
 
+	failoverStatus, err := s.ensureFailover(fedResource, clusters, selectedClusterNames)
+	if err != nil {
+		fedResource.RecordError(string(status.ComputePlacementFailed), errors.Wrap(err, "Failed to compute failover placement"))
+		runtime.HandleError(errors.Wrapf(err, "failed to compute failover placement"))
+		return s.setFederatedStatus(fedResource, status.ComputePlacementFailed, nil, nil, enableRawResourceStatusCollection)
+	}
+	selectedClusterNames.Insert(failoverStatus.Clusters...)
+
+	// Resources already present in clusters that are only excluded
+	// from placement by NoSchedule taints are kept up-to-date rather
+	// than removed.
+	retainedClusterNames, err := fedResource.ComputeRetainedPlacement(clusters, s.clustersWithTarget(fedResource, clusters))
+	if err != nil {
+		fedResource.RecordError(string(status.ComputePlacementFailed), errors.Wrap(err, "Failed to compute placement"))
+		runtime.HandleError(errors.Wrapf(err, "failed to compute placement"))
+		return s.setFederatedStatus(fedResource, status.ComputePlacementFailed, nil, nil, enableRawResourceStatusCollection)
+	}
+	versionedClusterNames := selectedClusterNames.Clone()
+
+	heldClusterNames, rolloutProgress, err := s.ensureRollout(fedResource, clusters, selectedClusterNames)
+	if err != nil {
+		fedResource.RecordError(string(status.ComputeRolloutFailed), errors.Wrap(err, "Failed to compute rollout"))
+		runtime.HandleError(errors.Wrapf(err, "failed to compute rollout"))
+		return s.setFederatedStatus(fedResource, status.ComputeRolloutFailed, nil, nil, enableRawResourceStatusCollection)
+	}
+
+	// A paused resource is neither propagated nor rolled back.
+	paused := fedResource.IsPaused()
+	if !paused {
+		rolledBack, err := s.ensureHistory(fedResource, clusters, selectedClusterNames)
+		if apierrors.IsConflict(err) {
+			// The cached revisions are out of date.
+			s.worker.EnqueueWithDelay(fedResource.FederatedName(), s.smallDelay)
+		} else if err != nil {
+			// Revision history does not affect propagation of the
+			// current form of the resource.
+			fedResource.RecordError("RevisionHistoryFailed", errors.Wrap(err, "Failed to update revision history"))
+			runtime.HandleError(errors.Wrapf(err, "failed to update revision history"))
+			s.worker.EnqueueForRetry(fedResource.FederatedName())
+		}
+		if rolledBack {
+			// The rolled back resource will be reconciled in response
+			// to its update.
+			return utils.StatusAllOK
+		}
+	}
+
 	kind := fedResource.TargetKind()
 	key := fedResource.TargetName().String()
 	klog.V(4).Infof("Ensuring %s %q in clusters: %s", kind, key, strings.Join(sets.List[string](selectedClusterNames), ","))
 
-	dispatcher := dispatch.NewManagedDispatcher(s.informer.GetClientForCluster, fedResource, s.skipAdoptingResources, enableRawResourceStatusCollection)
+	dispatcher := dispatch.NewManagedDispatcher(s.informer.GetClientForCluster, s.clusterLimiters, fedResource, s.skipAdoptingResources, enableRawResourceStatusCollection, s.typeConfig.GetServerSideApplyEnabled(), s.typeConfig.GetMinimalCacheEnabled())
 
+	var dependencies []dependencyState
+	var dependencyErr error
+	dependenciesRetrieved := false
+	waitingForDependencies := false
 	for _, cluster := range clusters {
 		clusterName := cluster.Name
 		selectedCluster := selectedClusterNames.Has(clusterName)
@@ -378,6 +476,26 @@ func (s *KubeFedSyncController) syncToClusters(fedResource FederatedResource) ut
 			clusterObj = rawClusterObj.(*unstructured.Unstructured)
 		}
 
+		if paused {
+			// Objects in clusters are left as they are, but their
+			// status continues to be reported.
+			recordedVersion, err := fedResource.VersionForCluster(clusterName)
+			if err != nil {
+				dispatcher.RecordClusterError(status.VersionRetrievalFailed, clusterName, err)
+				continue
+			}
+			propStatus, ok := pausedClusterStatus(clusterObj, selectedCluster, recordedVersion)
+			if !ok {
+				continue
+			}
+			var resourceStatus interface{}
+			if clusterObj != nil {
+				resourceStatus = clusterObj.Object[utils.StatusField]
+			}
+			dispatcher.RecordStatus(clusterName, propStatus, resourceStatus)
+			continue
+		}
+
 		// Resource should not exist in the named cluster
 		if !selectedCluster {
 			if clusterObj == nil {
@@ -389,6 +507,13 @@ func (s *KubeFedSyncController) syncToClusters(fedResource FederatedResource) ut
 				dispatcher.RecordStatus(clusterName, status.WaitingForRemoval, clusterObj.Object[utils.StatusField])
 				continue
 			}
+			if retainedClusterNames.Has(clusterName) {
+				// Resource is allowed to remain in a cluster tainted
+				// NoSchedule.
+				versionedClusterNames.Insert(clusterName)
+				dispatcher.Update(clusterName, clusterObj)
+				continue
+			}
 			if fedResource.IsNamespaceInHostCluster(clusterObj) {
 				// Host cluster namespace needs to have the managed
 				// label removed so it won't be cached anymore.
@@ -401,34 +526,118 @@ func (s *KubeFedSyncController) syncToClusters(fedResource FederatedResource) ut
 
 		// Resource should appear in the named cluster
 
+		if heldClusterNames.Has(clusterName) {
+			// Propagation is held back until the clusters earlier
+			// in the rollout are updated and healthy.
+			var resourceStatus interface{}
+			if clusterObj != nil {
+				resourceStatus = clusterObj.Object[utils.StatusField]
+			}
+			dispatcher.RecordStatus(clusterName, status.WaitingForRollout, resourceStatus)
+			continue
+		}
+
 		// TODO(marun) Consider waiting until the result of resource
 		// creation has reached the target store before attempting
 		// subsequent operations.  Otherwise the object won't be found
 		// but an add operation will fail with AlreadyExists.
 		if clusterObj == nil {
+			// Dependencies are only retrieved when the resource
+			// needs to be created in a cluster.
+			if !dependenciesRetrieved {
+				dependencies, dependencyErr = s.getDependencies(fedResource)
+				dependenciesRetrieved = true
+			}
+			if dependencyErr != nil {
+				dispatcher.RecordClusterError(status.DependencyRetrievalFailed, clusterName, dependencyErr)
+				continue
+			}
+			if unmet := unmetDependencies(dependencies, clusterName); len(unmet) > 0 {
+				klog.V(4).Infof("Waiting for %s to be propagated to cluster %q before creating %s %q", strings.Join(unmet, ", "), clusterName, kind, key)
+				dispatcher.RecordStatus(clusterName, status.WaitingForDependencies, nil)
+				waitingForDependencies = true
+				continue
+			}
 			dispatcher.Create(clusterName)
 		} else {
 			dispatcher.Update(clusterName, clusterObj)
 		}
 	}
+	if waitingForDependencies {
+		// The status of dependencies is not watched, so check
+		// again for their propagation after a delay.
+		s.worker.EnqueueWithDelay(fedResource.FederatedName(), s.smallDelay)
+	}
 	_, timeoutErr := dispatcher.Wait()
 	if timeoutErr != nil {
 		fedResource.RecordError("OperationTimeoutError", timeoutErr)
 		runtime.HandleError(errors.Wrapf(timeoutErr, "operation timeout"))
 	}
-	// Write updated versions to the API.
-	updatedVersionMap := dispatcher.VersionMap()
-	err = fedResource.UpdateVersions(sets.List[string](selectedClusterNames), updatedVersionMap)
-	if err != nil {
-		// Versioning of federated resources is an optimization to
-		// avoid unnecessary updates, and failure to record version
-		// information does not indicate a failure of propagation.
-		runtime.HandleError(err)
+	// Write updated versions to the API.  Nothing was propagated
+	// while paused, so the recorded versions remain valid.
+	if !paused {
+		updatedVersionMap := dispatcher.VersionMap()
+		err = fedResource.UpdateVersions(sets.List[string](versionedClusterNames), updatedVersionMap)
+		if err != nil {
+			// Versioning of federated resources is an optimization to
+			// avoid unnecessary updates, and failure to record version
+			// information does not indicate a failure of propagation.
+			runtime.HandleError(err)
+		}
 	}
 
 	collectedStatus, collectedResourceStatus := dispatcher.CollectedStatus()
+	collectedStatus.Failover = failoverStatus
+	collectedStatus.Rollout = rolloutProgress
+	reason := status.AggregateSuccess
+	if paused {
+		reason = status.PropagationPaused
+	}
 	klog.V(4).Infof("Setting the federated status '%v' for %s %q", collectedResourceStatus, kind, key)
-	return s.setFederatedStatus(fedResource, status.AggregateSuccess, &collectedStatus, &collectedResourceStatus, enableRawResourceStatusCollection)
+	return s.setFederatedStatus(fedResource, reason, &collectedStatus, &collectedResourceStatus, enableRawResourceStatusCollection)
+}
+
+// ensureFailover determines the standby clusters the given resource
+// should be propagated to in addition to its selected clusters,
+// records events when the use of standby clusters starts or stops,
+// and schedules reconciliation for when a pending revert is due.
+func (s *KubeFedSyncController) ensureFailover(fedResource FederatedResource, clusters []*fedv1b1.KubeFedCluster, selectedClusterNames sets.Set[string]) (*status.GenericFailoverStatus, error) {
+	policy, err := fedResource.FailoverPolicy()
+	if err != nil {
+		return nil, err
+	}
+	previous, err := status.GetFailoverStatus(fedResource.Object())
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil && previous == nil {
+		return &status.GenericFailoverStatus{}, nil
+	}
+
+	standbyClusterNames, err := fedResource.ComputeStandbyPlacement(clusters)
+	if err != nil {
+		return nil, err
+	}
+	readyClusterNames := sets.Set[string]{}
+	for _, cluster := range clusters {
+		if utils.IsClusterReady(&cluster.Status) {
+			readyClusterNames.Insert(cluster.Name)
+		}
+	}
+
+	failoverStatus, revertDelay := computeFailover(policy, previous, selectedClusterNames, standbyClusterNames, readyClusterNames, time.Now())
+	switch {
+	case failoverStatus.IsActive() && !previous.IsActive():
+		fedResource.RecordEvent("FailoverActivated", "Too few selected clusters are ready, using standby clusters: %s", strings.Join(failoverStatus.Clusters, ", "))
+	case !failoverStatus.IsActive() && previous.IsActive():
+		fedResource.RecordEvent("FailoverReverted", "No longer using standby clusters: %s", strings.Join(previous.Clusters, ", "))
+	case !failoverStatus.IsActive() && policy != nil && int64(selectedClusterNames.Intersection(readyClusterNames).Len()) < policy.MinReadyClusters:
+		fedResource.RecordError("FailoverUnavailable", errors.New("Too few selected clusters are ready and no standby cluster is ready"))
+	}
+	if revertDelay > 0 {
+		s.worker.EnqueueWithDelay(fedResource.FederatedName(), revertDelay)
+	}
+	return failoverStatus, nil
 }
 
 func (s *KubeFedSyncController) setFederatedStatus(fedResource FederatedResource,
@@ -529,7 +738,7 @@ func (s *KubeFedSyncController) ensureDeletion(fedResource FederatedResource) ut
 			runtime.HandleError(wrappedErr)
 			return utils.StatusError
 		}
-		targetClusters, err := fedResource.ComputePlacement(clusters)
+		targetClusters, err := computeManagedPlacement(fedResource, clusters, s.clustersWithTarget(fedResource, clusters))
 		if err != nil {
 			wrappedErr := errors.Wrapf(err, "failed to compute placement for %s %q", kind, key)
 			runtime.HandleError(wrappedErr)
@@ -565,6 +774,53 @@ func (s *KubeFedSyncController) ensureDeletion(fedResource FederatedResource) ut
 	return utils.StatusAllOK
 }
 
+// clustersWithTarget returns the names of the clusters whose cache
+// holds the target resource of the given federated resource.
+func (s *KubeFedSyncController) clustersWithTarget(fedResource FederatedResource, clusters []*fedv1b1.KubeFedCluster) sets.Set[string] {
+	key := fedResource.TargetName().String()
+	clusterNames := sets.Set[string]{}
+	for _, cluster := range clusters {
+		rawClusterObj, _, err := s.informer.GetTargetStore().GetByKey(cluster.Name, key)
+		if err == nil && rawClusterObj != nil {
+			clusterNames.Insert(cluster.Name)
+		}
+	}
+	return clusterNames
+}
+
+// computeManagedPlacement determines the clusters that may contain
+// resources managed by the given federated resource: the clusters in
+// which the resource may be retained and the standby clusters
+// recorded as in use by failover.
+func computeManagedPlacement(fedResource FederatedResource, clusters []*fedv1b1.KubeFedCluster, existingClusters sets.Set[string]) (sets.Set[string], error) {
+	clusterNames, err := fedResource.ComputeRetainedPlacement(clusters, existingClusters)
+	if errors.Cause(err) == errPlacementPolicyNotFound {
+		// The placement of a resource whose PlacementPolicy was
+		// removed is unknown, so any cluster may contain managed
+		// resources.
+		clusterNames, err = getClusterNames(clusters), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	failoverStatus, err := status.GetFailoverStatus(fedResource.Object())
+	if err != nil {
+		return nil, err
+	}
+	if failoverStatus != nil {
+		clusterNames.Insert(failoverStatus.Clusters...)
+	}
+	return clusterNames, nil
+}
+
+func getClusterNames(clusters []*fedv1b1.KubeFedCluster) sets.Set[string] {
+	clusterNames := sets.Set[string]{}
+	for _, cluster := range clusters {
+		clusterNames.Insert(cluster.Name)
+	}
+	return clusterNames
+}
+
 // removeManagedLabel attempts to remove the managed label from
 // resources with the given name in member clusters.
 func (s *KubeFedSyncController) removeManagedLabel(gvk schema.GroupVersionKind, qualifiedName utils.QualifiedName, clusters sets.Set[string]) error {
@@ -592,7 +848,7 @@ func (s *KubeFedSyncController) deleteFromClusters(fedResource FederatedResource
 	if err != nil {
 		return false, err
 	}
-	targetClusters, err := fedResource.ComputePlacement(clusters)
+	targetClusters, err := computeManagedPlacement(fedResource, clusters, s.clustersWithTarget(fedResource, clusters))
 	if err != nil {
 		return false, err
 	}
@@ -659,12 +915,12 @@ func (s *KubeFedSyncController) ensureRemovedOrUnmanaged(fedResource FederatedRe
 		return errors.Wrap(err, "failed to get a list of clusters")
 	}
 
-	targetClusters, err := fedResource.ComputePlacement(clusters)
+	targetClusters, err := computeManagedPlacement(fedResource, clusters, s.clustersWithTarget(fedResource, clusters))
 	if err != nil {
 		return errors.Wrapf(err, "failed to compute placement for %s %q", fedResource.FederatedKind(), fedResource.FederatedName().Name)
 	}
 
-	dispatcher := dispatch.NewCheckUnmanagedDispatcher(s.informer.GetClientForCluster, fedResource.TargetGVK(), fedResource.TargetName())
+	dispatcher := dispatch.NewCheckUnmanagedDispatcher(s.informer.GetClientForCluster, s.clusterLimiters, fedResource.TargetGVK(), fedResource.TargetName())
 
 	// 定义未就绪集群列表
 	var unreadyClusters []string
@@ -700,7 +956,7 @@ func (s *KubeFedSyncController) handleDeletionInClusters(gvk schema.GroupVersion
 		return false, errors.Wrap(err, "failed to get a list of clusters")
 	}
 
-	dispatcher := dispatch.NewUnmanagedDispatcher(s.informer.GetClientForCluster, gvk, qualifiedName)
+	dispatcher := dispatch.NewUnmanagedDispatcher(s.informer.GetClientForCluster, s.clusterLimiters, gvk, qualifiedName)
 	var (
 		unreadyClusters          []string
 		retrievalFailureClusters []string
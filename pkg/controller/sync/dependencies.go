@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+// dependencyState is a dependency of a federated resource and the
+// propagation status of the federated resource it references.
+type dependencyState struct {
+	dependency utils.GenericDependency
+	// found indicates whether the referenced resource exists.
+	found bool
+	// current indicates whether the status of the referenced resource
+	// reflects its latest generation.
+	current bool
+	// clusterStatus is the propagation status of the referenced
+	// resource for each cluster it is placed in.
+	clusterStatus map[string]status.PropagationStatus
+}
+
+// getDependencies retrieves the federated resources the given resource
+// depends on.
+func (s *KubeFedSyncController) getDependencies(fedResource FederatedResource) ([]dependencyState, error) {
+	dependencies, err := utils.GetDependencies(fedResource.Object())
+	if err != nil {
+		return nil, err
+	}
+	return getDependencyStates(s.hostClusterClient, dependencies)
+}
+
+// getDependencyStates retrieves the federated resources referenced by
+// the given dependencies.  The resource of an inferred dependency is
+// considered not found if its federated type is not installed, e.g.
+// because propagation of the type has been disabled.
+func getDependencyStates(client genericclient.Client, dependencies []utils.GenericDependency) ([]dependencyState, error) {
+	var states []dependencyState
+	for _, dependency := range dependencies {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(dependency.APIVersion)
+		obj.SetKind(dependency.Kind)
+		err := client.Get(context.TODO(), obj, dependency.Namespace, dependency.Name)
+		if apierrors.IsNotFound(err) || dependency.Inferred && meta.IsNoMatchError(err) {
+			states = append(states, dependencyState{dependency: dependency})
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve dependency %s", dependencyName(dependency))
+		}
+		state, err := newDependencyState(dependency, obj)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, *state)
+	}
+	return states, nil
+}
+
+func newDependencyState(dependency utils.GenericDependency, obj *unstructured.Unstructured) (*dependencyState, error) {
+	resource := &status.GenericFederatedResource{}
+	err := utils.UnstructuredToInterface(obj, resource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the status of dependency %s", dependencyName(dependency))
+	}
+	state := &dependencyState{
+		dependency:    dependency,
+		found:         true,
+		clusterStatus: map[string]status.PropagationStatus{},
+	}
+	if resource.Status != nil {
+		state.current = resource.Status.ObservedGeneration >= obj.GetGeneration()
+		for _, cluster := range resource.Status.Clusters {
+			state.clusterStatus[cluster.Name] = cluster.Status
+		}
+	}
+	return state, nil
+}
+
+// unmetDependencies returns the names of the given dependencies that
+// have not been propagated to the named cluster.  A declared dependency
+// is met once the referenced resource reports ClusterPropagationOK for
+// the cluster.  An inferred dependency is only required to be met if
+// the referenced resource exists and is placed in the cluster.
+func unmetDependencies(states []dependencyState, clusterName string) []string {
+	var unmet []string
+	for _, state := range states {
+		if !state.found {
+			if !state.dependency.Inferred {
+				unmet = append(unmet, dependencyName(state.dependency))
+			}
+			continue
+		}
+		clusterStatus, placed := state.clusterStatus[clusterName]
+		switch {
+		case !state.current:
+			// Placement of the dependency may have changed.
+		case placed && clusterStatus == status.ClusterPropagationOK:
+			continue
+		case !placed && state.dependency.Inferred:
+			continue
+		}
+		unmet = append(unmet, dependencyName(state.dependency))
+	}
+	return unmet
+}
+
+func dependencyName(dependency utils.GenericDependency) string {
+	qualifiedName := utils.QualifiedName{Namespace: dependency.Namespace, Name: dependency.Name}
+	return fmt.Sprintf("%s %q", dependency.Kind, qualifiedName)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+func TestUnmetDependencies(t *testing.T) {
+	newState := func(inferred bool, generation, observedGeneration int64, clusters ...interface{}) dependencyState {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"observedGeneration": observedGeneration,
+				"clusters":           clusters,
+			},
+		}}
+		obj.SetGeneration(generation)
+		dependency := utils.GenericDependency{Kind: "FederatedConfigMap", Namespace: "ns", Name: "config", Inferred: inferred}
+		state, err := newDependencyState(dependency, obj)
+		if err != nil {
+			t.Fatalf("An unexpected error occurred: %v", err)
+		}
+		return *state
+	}
+	propagated := map[string]interface{}{"name": "cluster1"}
+	failed := map[string]interface{}{"name": "cluster1", "status": string(status.CreationFailed)}
+	unmet := []string{`FederatedConfigMap "ns/config"`}
+
+	testCases := map[string]struct {
+		state    dependencyState
+		expected []string
+	}{
+		"declared dependency propagated": {
+			state: newState(false, 1, 1, propagated),
+		},
+		"declared dependency failed": {
+			state:    newState(false, 1, 1, failed),
+			expected: unmet,
+		},
+		"declared dependency not placed": {
+			state:    newState(false, 1, 1),
+			expected: unmet,
+		},
+		"declared dependency not found": {
+			state: dependencyState{
+				dependency: utils.GenericDependency{Kind: "FederatedConfigMap", Namespace: "ns", Name: "config"},
+			},
+			expected: unmet,
+		},
+		"dependency status is stale": {
+			state:    newState(false, 2, 1, propagated),
+			expected: unmet,
+		},
+		"inferred dependency not placed": {
+			state: newState(true, 1, 1),
+		},
+		"inferred dependency failed": {
+			state:    newState(true, 1, 1, failed),
+			expected: unmet,
+		},
+		"inferred dependency not found": {
+			state: dependencyState{
+				dependency: utils.GenericDependency{Kind: "FederatedConfigMap", Namespace: "ns", Name: "config", Inferred: true},
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			result := unmetDependencies([]dependencyState{testCase.state}, "cluster1")
+			if !reflect.DeepEqual(result, testCase.expected) {
+				t.Fatalf("Expected unmet dependencies %v, got %v", testCase.expected, result)
+			}
+		})
+	}
+}
+
+// noMatchClient fails to retrieve resources as if their types were
+// not installed.
+type noMatchClient struct {
+	genericclient.Client
+}
+
+func (c *noMatchClient) Get(_ context.Context, obj runtimeclient.Object, _, _ string) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+}
+
+func TestGetDependencyStatesTypeNotInstalled(t *testing.T) {
+	dependency := utils.GenericDependency{
+		APIVersion: schema.GroupVersion{Group: "types.kubefed.io", Version: "v1beta1"}.String(),
+		Kind:       "FederatedConfigMap",
+		Namespace:  "ns",
+		Name:       "config",
+	}
+
+	inferred := dependency
+	inferred.Inferred = true
+	states, err := getDependencyStates(&noMatchClient{}, []utils.GenericDependency{inferred})
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if len(states) != 1 || states[0].found {
+		t.Fatalf("Expected an inferred dependency of a type that is not installed not to be found, got %v", states)
+	}
+	if unmet := unmetDependencies(states, "cluster1"); len(unmet) != 0 {
+		t.Fatalf("Expected no unmet dependencies, got %v", unmet)
+	}
+
+	if _, err := getDependencyStates(&noMatchClient{}, []utils.GenericDependency{dependency}); err == nil {
+		t.Fatalf("Expected an error for a declared dependency of a type that is not installed")
+	}
+}
@@ -47,8 +47,8 @@ type checkUnmanagedDispatcherImpl struct {
 	targetName utils.QualifiedName
 }
 
-func NewCheckUnmanagedDispatcher(clientAccessor clientAccessorFunc, targetGVK schema.GroupVersionKind, targetName utils.QualifiedName) CheckUnmanagedDispatcher {
-	dispatcher := newOperationDispatcher(clientAccessor, nil)
+func NewCheckUnmanagedDispatcher(clientAccessor clientAccessorFunc, limiters *utils.ClusterLimiters, targetGVK schema.GroupVersionKind, targetName utils.QualifiedName) CheckUnmanagedDispatcher {
+	dispatcher := newOperationDispatcher(clientAccessor, limiters, nil)
 	return &checkUnmanagedDispatcherImpl{
 		dispatcher: dispatcher,
 		targetGVK:  targetGVK,
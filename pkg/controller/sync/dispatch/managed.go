@@ -45,6 +45,7 @@ import (
 type FederatedResourceForDispatch interface {
 	TargetName() utils.QualifiedName
 	TargetKind() string
+	RetainFields() []string
 	TargetGVK() schema.GroupVersionKind
 	Object() *unstructured.Unstructured
 	VersionForCluster(clusterName string) (string, error)
@@ -78,8 +79,18 @@ type managedDispatcherImpl struct {
 	versionMap            map[string]string
 	statusMap             status.PropagationStatusMap
 	resourceStatusMap     map[string]interface{}
+	driftedFieldsMap      map[string][]string
 	skipAdoptingResources bool
 
+	// Whether objects are written to member clusters with server-side
+	// apply instead of create and update.
+	serverSideApply bool
+
+	// Whether cluster objects are cached without the fields not
+	// needed for propagation, requiring drift to be determined against
+	// the object retrieved from the member cluster.
+	minimalCache bool
+
 	// Track when resource updates are performed to allow indicating
 	// when a change was last propagated to member clusters.
 	resourcesUpdated bool
@@ -87,16 +98,19 @@ type managedDispatcherImpl struct {
 	rawResourceStatusCollection bool
 }
 
-func NewManagedDispatcher(clientAccessor clientAccessorFunc, fedResource FederatedResourceForDispatch, skipAdoptingResources, rawResourceStatusCollection bool) ManagedDispatcher {
+func NewManagedDispatcher(clientAccessor clientAccessorFunc, limiters *utils.ClusterLimiters, fedResource FederatedResourceForDispatch, skipAdoptingResources, rawResourceStatusCollection, serverSideApply, minimalCache bool) ManagedDispatcher {
 	d := &managedDispatcherImpl{
 		fedResource:                 fedResource,
 		versionMap:                  make(map[string]string),
 		statusMap:                   make(status.PropagationStatusMap),
 		resourceStatusMap:           make(map[string]interface{}),
+		driftedFieldsMap:            make(map[string][]string),
 		skipAdoptingResources:       skipAdoptingResources,
 		rawResourceStatusCollection: rawResourceStatusCollection,
+		serverSideApply:             serverSideApply,
+		minimalCache:                minimalCache,
 	}
-	d.dispatcher = newOperationDispatcher(clientAccessor, d)
+	d.dispatcher = newOperationDispatcher(clientAccessor, limiters, d)
 	d.unmanagedDispatcher = newUnmanagedDispatcher(d.dispatcher, d, fedResource.TargetGVK(), fedResource.TargetName())
 	return d
 }
@@ -157,6 +171,10 @@ func (d *managedDispatcherImpl) Create(clusterName string) {
 			return d.recordOperationError(status.ApplyOverridesFailed, clusterName, op, err)
 		}
 
+		if d.serverSideApply {
+			return d.createWithApply(client, clusterName, obj, start)
+		}
+
 		err = client.Create(context.Background(), obj)
 		if err == nil {
 			version := utils.ObjectVersion(obj)
@@ -181,18 +199,54 @@ func (d *managedDispatcherImpl) Create(clusterName string) {
 			return d.recordOperationError(status.RetrievalFailed, clusterName, op, wrappedErr)
 		}
 
-		d.RecordStatus(clusterName, status.CreationTimedOut, obj.Object[utils.StatusField])
+		return d.adopt(clusterName, obj, start)
+	})
+}
 
-		if d.skipAdoptingResources && !d.fedResource.IsNamespaceInHostCluster(obj) {
-			_ = d.recordOperationError(status.AlreadyExists, clusterName, op, errors.Errorf("Resource pre-exist in cluster"))
-			return utils.StatusAllOK
-		}
+// createWithApply creates the object in the named cluster with
+// server-side apply.  Since an apply would silently take over an
+// existing resource, the cluster is checked for the resource first so
+// that adoption is handled in the same way as for a creation.
+func (d *managedDispatcherImpl) createWithApply(client generic.Client, clusterName string, obj *unstructured.Unstructured, start time.Time) utils.ReconciliationStatus {
+	const op = "create"
+	clusterObj := &unstructured.Unstructured{}
+	clusterObj.SetGroupVersionKind(obj.GroupVersionKind())
+	err := client.Get(context.Background(), clusterObj, obj.GetNamespace(), obj.GetName())
+	if err == nil {
+		return d.adopt(clusterName, clusterObj, start)
+	}
+	if !apierrors.IsNotFound(err) {
+		wrappedErr := errors.Wrapf(err, "failed to retrieve object potentially requiring adoption")
+		return d.recordOperationError(status.RetrievalFailed, clusterName, op, wrappedErr)
+	}
 
-		d.recordError(clusterName, op, errors.Errorf("An update will be attempted instead of a creation due to an existing resource"))
-		d.Update(clusterName, obj)
-		metrics.DispatchOperationDurationFromStart("update", start)
+	err = applyObject(client, obj)
+	if err != nil {
+		return d.recordOperationError(status.CreationFailed, clusterName, op, err)
+	}
+	version := utils.ObjectVersion(obj)
+	d.recordVersion(clusterName, version)
+	d.RecordStatus(clusterName, status.CreationTimedOut, obj.Object[utils.StatusField])
+	metrics.DispatchOperationDurationFromStart("create", start)
+	return utils.StatusAllOK
+}
+
+// adopt attempts to update an existing resource that was found in
+// place of a creation to ensure that it is labeled as a managed
+// resource.
+func (d *managedDispatcherImpl) adopt(clusterName string, clusterObj *unstructured.Unstructured, start time.Time) utils.ReconciliationStatus {
+	const op = "create"
+	d.RecordStatus(clusterName, status.CreationTimedOut, clusterObj.Object[utils.StatusField])
+
+	if d.skipAdoptingResources && !d.fedResource.IsNamespaceInHostCluster(clusterObj) {
+		_ = d.recordOperationError(status.AlreadyExists, clusterName, op, errors.Errorf("Resource pre-exist in cluster"))
 		return utils.StatusAllOK
-	})
+	}
+
+	d.recordError(clusterName, op, errors.Errorf("An update will be attempted instead of a creation due to an existing resource"))
+	d.Update(clusterName, clusterObj)
+	metrics.DispatchOperationDurationFromStart("update", start)
+	return utils.StatusAllOK
 }
 
 func (d *managedDispatcherImpl) Update(clusterName string, clusterObj *unstructured.Unstructured) {
@@ -211,7 +265,11 @@ func (d *managedDispatcherImpl) Update(clusterName string, clusterObj *unstructu
 			return d.recordOperationError(status.ComputeResourceFailed, clusterName, op, err)
 		}
 
-		err = RetainClusterFields(d.fedResource.TargetKind(), obj, clusterObj, d.fedResource.Object())
+		if d.serverSideApply {
+			err = RetainClusterFieldsForApply(d.fedResource.RetainFields(), obj, clusterObj, d.fedResource.Object())
+		} else {
+			err = RetainClusterFields(d.fedResource.TargetKind(), d.fedResource.RetainFields(), obj, clusterObj, d.fedResource.Object())
+		}
 		if err != nil {
 			wrappedErr := errors.Wrapf(err, "failed to retain fields")
 			return d.recordOperationError(status.FieldRetentionFailed, clusterName, op, wrappedErr)
@@ -226,16 +284,43 @@ func (d *managedDispatcherImpl) Update(clusterName string, clusterObj *unstructu
 		if err != nil {
 			return d.recordOperationError(status.VersionRetrievalFailed, clusterName, op, err)
 		}
-		if !utils.ObjectNeedsUpdate(obj, clusterObj, version) {
+		desiredObj := obj
+		if d.serverSideApply {
+			// Metadata set in the member cluster is not part of the
+			// applied object but is left alone by the apply.
+			desiredObj = WithRetainedMetadata(obj, clusterObj)
+		}
+		if !utils.ObjectNeedsUpdate(desiredObj, clusterObj, version) {
 			// Resource is current
 			d.RecordStatus(clusterName, status.UpdateTimedOut, clusterObj.Object[utils.StatusField])
 			return utils.StatusAllOK
 		}
 
+		// A recorded version indicates that the federated resource is
+		// unchanged since it was last propagated, so the difference is
+		// due to a change made in the member cluster.
+		if version != "" && d.reportsDrift() {
+			if d.minimalCache {
+				liveObj, err := d.getClusterObject(client, clusterName)
+				if err != nil {
+					return d.recordOperationError(status.RetrievalFailed, clusterName, op, err)
+				}
+				if d.serverSideApply {
+					desiredObj = WithRetainedMetadata(obj, liveObj)
+				}
+				return d.reportDrift(clusterName, desiredObj, liveObj)
+			}
+			return d.reportDrift(clusterName, desiredObj, clusterObj)
+		}
+
 		// Only record an event if the resource is not current
 		d.recordEvent(clusterName, op, "Updating")
 
-		err = client.Update(context.Background(), obj)
+		if d.serverSideApply {
+			err = applyObject(client, obj)
+		} else {
+			err = client.Update(context.Background(), obj)
+		}
 		if err != nil {
 			return d.recordOperationError(status.UpdateFailed, clusterName, op, err)
 		}
@@ -247,6 +332,89 @@ func (d *managedDispatcherImpl) Update(clusterName string, clusterObj *unstructu
 	})
 }
 
+// getClusterObject retrieves the resource from the member cluster,
+// including the fields that are not cached.
+func (d *managedDispatcherImpl) getClusterObject(client generic.Client, clusterName string) (*unstructured.Unstructured, error) {
+	targetName := d.unmanagedDispatcher.targetNameForCluster(clusterName)
+	clusterObj := &unstructured.Unstructured{}
+	clusterObj.SetGroupVersionKind(d.fedResource.TargetGVK())
+	err := client.Get(context.Background(), clusterObj, targetName.Namespace, targetName.Name)
+	return clusterObj, err
+}
+
+// reportsDrift returns whether changes to resources in member clusters
+// should be reported instead of reverted.
+func (d *managedDispatcherImpl) reportsDrift() bool {
+	driftPolicy, _, _ := unstructured.NestedString(d.fedResource.Object().Object, utils.SpecField, utils.DriftPolicyField)
+	return driftPolicy == utils.DriftPolicyReport
+}
+
+// reportDrift records the fields of the cluster object that differ
+// from the desired object instead of updating the cluster object.  If
+// no field differs, the current version of the cluster object is
+// recorded as propagated so that it is not compared again.
+func (d *managedDispatcherImpl) reportDrift(clusterName string, desiredObj, clusterObj *unstructured.Unstructured) utils.ReconciliationStatus {
+	driftedFields := utils.DriftedFields(desiredObj, clusterObj)
+	if len(driftedFields) == 0 {
+		d.recordVersion(clusterName, utils.ObjectVersion(clusterObj))
+		d.RecordStatus(clusterName, status.UpdateTimedOut, clusterObj.Object[utils.StatusField])
+		return utils.StatusAllOK
+	}
+
+	d.Lock()
+	d.driftedFieldsMap[clusterName] = driftedFields
+	d.Unlock()
+	d.RecordStatus(clusterName, status.Drifted, clusterObj.Object[utils.StatusField])
+	return utils.StatusAllOK
+}
+
+// applyObject writes the given object to a member cluster with
+// server-side apply.  Conflicts with other field managers are resolved
+// in favor of the sync controller, since the federated resource is the
+// source of truth for the fields it sets.
+func applyObject(client generic.Client, obj *unstructured.Unstructured) error {
+	return client.Apply(context.Background(), runtimeclient.ApplyConfigurationFromUnstructured(obj),
+		runtimeclient.FieldOwner(utils.SyncFieldManager), runtimeclient.ForceOwnership)
+}
+
+// WithRetainedMetadata returns a copy of the desired object with the
+// labels, annotations and finalizers of the cluster object that the
+// desired object does not set, i.e. the metadata the cluster object
+// would have after a server-side apply of the desired object.
+func WithRetainedMetadata(desiredObj, clusterObj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj := desiredObj.DeepCopy()
+	obj.SetLabels(mergeStringMaps(clusterObj.GetLabels(), desiredObj.GetLabels()))
+	obj.SetAnnotations(mergeStringMaps(clusterObj.GetAnnotations(), desiredObj.GetAnnotations()))
+	obj.SetFinalizers(mergeStringSlices(clusterObj.GetFinalizers(), desiredObj.GetFinalizers()))
+	return obj
+}
+
+func mergeStringMaps(base, overlay map[string]string) map[string]string {
+	if len(base) == 0 {
+		return overlay
+	}
+	merged := make(map[string]string, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overlay {
+		merged[key] = value
+	}
+	return merged
+}
+
+func mergeStringSlices(base, overlay []string) []string {
+	var merged []string
+	merged = append(merged, base...)
+	existing := sets.New(base...)
+	for _, value := range overlay {
+		if !existing.Has(value) {
+			merged = append(merged, value)
+		}
+	}
+	return merged
+}
+
 func (d *managedDispatcherImpl) Delete(clusterName string, opts ...runtimeclient.DeleteOption) {
 	d.RecordStatus(clusterName, status.DeletionTimedOut, nil)
 
@@ -328,8 +496,12 @@ func (d *managedDispatcherImpl) CollectedStatus() (status.CollectedPropagationSt
 	defer d.RUnlock()
 	statusMap := make(status.PropagationStatusMap)
 	resourceStatusMap := make(map[string]interface{})
+	driftedFieldsMap := make(map[string][]string)
 	for key, value := range d.statusMap {
 		statusMap[key] = value
+		if value == status.Drifted {
+			driftedFieldsMap[key] = d.driftedFieldsMap[key]
+		}
 	}
 
 	for key, value := range d.resourceStatusMap {
@@ -338,6 +510,7 @@ func (d *managedDispatcherImpl) CollectedStatus() (status.CollectedPropagationSt
 	return status.CollectedPropagationStatus{
 			StatusMap:        statusMap,
 			ResourcesUpdated: d.resourcesUpdated,
+			DriftedFieldsMap: driftedFieldsMap,
 		}, status.CollectedResourceStatus{
 			StatusMap:        resourceStatusMap,
 			ResourcesUpdated: d.resourcesUpdated,
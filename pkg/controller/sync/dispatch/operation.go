@@ -17,6 +17,7 @@ limitations under the License.
 package dispatch
 
 import (
+	"context"
 	"sync/atomic"
 	"time"
 
@@ -48,6 +49,10 @@ type OperationDispatcher interface {
 type operationDispatcherImpl struct {
 	clientAccessor clientAccessorFunc
 
+	// limiters limits the rate and concurrency of the operations
+	// dispatched to each cluster.
+	limiters *utils.ClusterLimiters
+
 	resultChan          chan utils.ReconciliationStatus
 	operationsInitiated int32
 
@@ -56,9 +61,10 @@ type operationDispatcherImpl struct {
 	recorder dispatchRecorder
 }
 
-func newOperationDispatcher(clientAccessor clientAccessorFunc, recorder dispatchRecorder) *operationDispatcherImpl {
+func newOperationDispatcher(clientAccessor clientAccessorFunc, limiters *utils.ClusterLimiters, recorder dispatchRecorder) *operationDispatcherImpl {
 	return &operationDispatcherImpl{
 		clientAccessor: clientAccessor,
+		limiters:       limiters,
 		resultChan:     make(chan utils.ReconciliationStatus),
 		timeout:        30 * time.Second, // TODO(marun) Make this configurable
 		recorder:       recorder,
@@ -106,6 +112,22 @@ func (d *operationDispatcherImpl) clusterOperation(clusterName, op string, opFun
 		return
 	}
 
+	// Operations that cannot be started before the dispatcher times
+	// out are abandoned rather than left waiting on the limiter.
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+	release, err := d.limiters.Acquire(ctx, clusterName)
+	if err != nil {
+		if d.recorder == nil {
+			runtime.HandleError(err)
+		} else {
+			d.recorder.recordOperationError(status.RateLimited, clusterName, op, err)
+		}
+		d.resultChan <- utils.StatusError
+		return
+	}
+	defer release()
+
 	// TODO(marun) Retry on recoverable errors (e.g. IsConflict, AlreadyExists)
 	ok := opFunc(client)
 	d.resultChan <- ok
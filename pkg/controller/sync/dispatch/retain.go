@@ -17,6 +17,8 @@ limitations under the License.
 package dispatch
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -25,8 +27,8 @@ import (
 )
 
 // RetainClusterFields updates the desired object with values retained
-// from the cluster object.
-func RetainClusterFields(targetKind string, desiredObj, clusterObj, fedObj *unstructured.Unstructured) error {
+// from the cluster object, including the fields at the given paths.
+func RetainClusterFields(targetKind string, retainFieldPaths []string, desiredObj, clusterObj, fedObj *unstructured.Unstructured) error {
 	// Pass the same ResourceVersion as in the cluster object for update operation, otherwise operation will fail.
 	desiredObj.SetResourceVersion(clusterObj.GetResourceVersion())
 
@@ -36,6 +38,10 @@ func RetainClusterFields(targetKind string, desiredObj, clusterObj, fedObj *unst
 	desiredObj.SetFinalizers(clusterObj.GetFinalizers())
 	desiredObj.SetAnnotations(clusterObj.GetAnnotations())
 
+	if err := retainFields(desiredObj, clusterObj, retainFieldPaths); err != nil {
+		return err
+	}
+
 	if targetKind == utils.ServiceKind {
 		return retainServiceFields(desiredObj, clusterObj)
 	}
@@ -45,6 +51,60 @@ func RetainClusterFields(targetKind string, desiredObj, clusterObj, fedObj *unst
 	return retainReplicas(desiredObj, clusterObj, fedObj)
 }
 
+// RetainClusterFieldsForApply updates the desired object with values
+// retained from the cluster object when the object is written with
+// server-side apply.  Fields set by controllers in a member cluster
+// are owned by other field managers and are left alone by the apply,
+// so only the replicas field and the fields at the given paths need to
+// be retained.
+func RetainClusterFieldsForApply(retainFieldPaths []string, desiredObj, clusterObj, fedObj *unstructured.Unstructured) error {
+	retainsReplicas, err := shouldRetainReplicas(fedObj)
+	if err != nil {
+		return err
+	}
+	// Retained values are taken from the cached cluster object and
+	// asserted by the apply, so the apply is conditioned on the
+	// ResourceVersion of the cluster object to fail with a conflict
+	// rather than overwrite newer values, e.g. replicas scaled by an
+	// HPA.  An apply without retained values is not subject to
+	// optimistic concurrency.
+	if len(retainFieldPaths) > 0 || retainsReplicas {
+		desiredObj.SetResourceVersion(clusterObj.GetResourceVersion())
+	} else {
+		desiredObj.SetResourceVersion("")
+	}
+
+	if err := retainFields(desiredObj, clusterObj, retainFieldPaths); err != nil {
+		return err
+	}
+	return retainReplicas(desiredObj, clusterObj, fedObj)
+}
+
+// retainFields copies the values of the fields at the given JSON
+// pointers from the cluster object to the desired object.  A field that
+// is not set in the cluster object is left as it is in the desired
+// object.
+func retainFields(desiredObj, clusterObj *unstructured.Unstructured, paths []string) error {
+	for _, path := range paths {
+		fields := strings.Split(strings.TrimPrefix(path, "/"), "/")
+		for i, field := range fields {
+			fields[i] = strings.ReplaceAll(strings.ReplaceAll(field, "~1", "/"), "~0", "~")
+		}
+		value, ok, err := unstructured.NestedFieldCopy(clusterObj.Object, fields...)
+		if err != nil {
+			return errors.Wrapf(err, "Error retrieving %s from cluster object", path)
+		}
+		if !ok {
+			continue
+		}
+		err = unstructured.SetNestedField(desiredObj.Object, value, fields...)
+		if err != nil {
+			return errors.Wrapf(err, "Error setting %s", path)
+		}
+	}
+	return nil
+}
+
 func retainServiceFields(desiredObj, clusterObj *unstructured.Unstructured) error {
 	// healthCheckNodePort is allocated by APIServer and unchangeable, so it should be retained while updating
 	healthCheckNodePort, ok, err := unstructured.NestedInt64(clusterObj.Object, utils.SpecField, utils.HealthCheckNodePortField)
@@ -159,11 +219,11 @@ func retainReplicas(desiredObj, clusterObj, fedObj *unstructured.Unstructured) e
 	// configured to do so.  If the replicas field is intended to be
 	// set by the in-cluster HPA controller, not retaining it will
 	// thrash the scheduler.
-	retainReplicas, ok, err := unstructured.NestedBool(fedObj.Object, utils.SpecField, utils.RetainReplicasField)
+	retainReplicas, err := shouldRetainReplicas(fedObj)
 	if err != nil {
 		return err
 	}
-	if ok && retainReplicas {
+	if retainReplicas {
 		replicas, ok, err := unstructured.NestedInt64(clusterObj.Object, utils.SpecField, utils.ReplicasField)
 		if err != nil {
 			return err
@@ -177,3 +237,13 @@ func retainReplicas(desiredObj, clusterObj, fedObj *unstructured.Unstructured) e
 	}
 	return nil
 }
+
+// shouldRetainReplicas returns whether the federated object has been
+// configured to retain the replicas field of cluster objects.
+func shouldRetainReplicas(fedObj *unstructured.Unstructured) (bool, error) {
+	retainReplicas, ok, err := unstructured.NestedBool(fedObj.Object, utils.SpecField, utils.RetainReplicasField)
+	if err != nil {
+		return false, err
+	}
+	return ok && retainReplicas, nil
+}
@@ -71,7 +71,7 @@ func TestRetainClusterFields(t *testing.T) {
 					},
 				},
 			}
-			if err := RetainClusterFields("", desiredObj, clusterObj, fedObj); err != nil {
+			if err := RetainClusterFields("", nil, desiredObj, clusterObj, fedObj); err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
 
@@ -89,6 +89,142 @@ func TestRetainClusterFields(t *testing.T) {
 	}
 }
 
+func TestRetainFields(t *testing.T) {
+	testCases := map[string]struct {
+		paths         []string
+		clusterObj    map[string]interface{}
+		expectedObj   map[string]interface{}
+		expectedError bool
+	}{
+		"no paths": {
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"host": "foo"},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{"host": "bar"},
+			},
+		},
+		"field retained": {
+			paths: []string{"/spec/host"},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"host": "foo"},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{"host": "foo"},
+			},
+		},
+		"nested field retained": {
+			paths: []string{"/spec/allocation/a~1b"},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"allocation": map[string]interface{}{"a/b": int64(1)},
+				},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"host":       "bar",
+					"allocation": map[string]interface{}{"a/b": int64(1)},
+				},
+			},
+		},
+		"field not set in cluster object": {
+			paths: []string{"/spec/host"},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{"host": "bar"},
+			},
+		},
+		"path traverses a non-object": {
+			paths: []string{"/spec/host/foo"},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"host": "foo"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			desiredObj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{"host": "bar"},
+				},
+			}
+			clusterObj := &unstructured.Unstructured{Object: testCase.clusterObj}
+			err := retainFields(desiredObj, clusterObj, testCase.paths)
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatalf("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			if !reflect.DeepEqual(desiredObj.Object, testCase.expectedObj) {
+				t.Fatalf("Expected %v, got %v", testCase.expectedObj, desiredObj.Object)
+			}
+		})
+	}
+}
+
+func TestRetainClusterFieldsForApply(t *testing.T) {
+	desiredObj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas":  int64(1),
+				"clusterIP": "",
+			},
+		},
+	}
+	clusterObj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas":  int64(2),
+				"clusterIP": "10.0.0.1",
+			},
+		},
+	}
+	clusterObj.SetResourceVersion("42")
+	clusterObj.SetAnnotations(map[string]string{"foo": "bar"})
+	fedObj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"retainReplicas": true,
+			},
+		},
+	}
+	if err := RetainClusterFieldsForApply(nil, desiredObj, clusterObj, fedObj); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if desiredObj.GetResourceVersion() != "42" {
+		t.Fatalf("Expected the resource version of the cluster object to be a precondition of retained replicas, got %q", desiredObj.GetResourceVersion())
+	}
+	if len(desiredObj.GetAnnotations()) != 0 {
+		t.Fatalf("Expected no annotations, got %v", desiredObj.GetAnnotations())
+	}
+	clusterIP, _, _ := unstructured.NestedString(desiredObj.Object, utils.SpecField, utils.ClusterIPField)
+	if clusterIP != "" {
+		t.Fatalf("Expected the clusterIP not to be retained, got %q", clusterIP)
+	}
+	replicas, _, _ := unstructured.NestedInt64(desiredObj.Object, utils.SpecField, utils.ReplicasField)
+	if replicas != 2 {
+		t.Fatalf("Expected 2 replicas when retainReplicas=true, got %d", replicas)
+	}
+
+	// Without retained values the apply is unconditional.
+	desiredObj = &unstructured.Unstructured{Object: map[string]interface{}{}}
+	if err := RetainClusterFieldsForApply(nil, desiredObj, clusterObj, &unstructured.Unstructured{Object: map[string]interface{}{}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if desiredObj.GetResourceVersion() != "" {
+		t.Fatalf("Expected no resource version, got %q", desiredObj.GetResourceVersion())
+	}
+}
+
 func TestRetainHealthCheckNodePortInServiceFields(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -56,8 +56,8 @@ type unmanagedDispatcherImpl struct {
 	recorder dispatchRecorder
 }
 
-func NewUnmanagedDispatcher(clientAccessor clientAccessorFunc, targetGVK schema.GroupVersionKind, targetName utils.QualifiedName) UnmanagedDispatcher {
-	dispatcher := newOperationDispatcher(clientAccessor, nil)
+func NewUnmanagedDispatcher(clientAccessor clientAccessorFunc, limiters *utils.ClusterLimiters, targetGVK schema.GroupVersionKind, targetName utils.QualifiedName) UnmanagedDispatcher {
+	dispatcher := newOperationDispatcher(clientAccessor, limiters, nil)
 	return newUnmanagedDispatcher(dispatcher, nil, targetGVK, targetName)
 }
 
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+// computeFailover determines the standby clusters to use in addition
+// to the selected clusters of a resource with the given failover
+// policy.
+//
+// Standby clusters are used while fewer than the minimum number of
+// selected clusters are ready, preferring standby clusters that are
+// already in use to avoid moving workloads needlessly.  Once enough
+// selected clusters are ready again, standby clusters remain in use
+// until the revert grace period has elapsed.  The returned duration
+// is the time remaining until the revert, or zero if no revert is
+// pending.
+func computeFailover(policy *utils.GenericFailoverPolicy, previous *status.GenericFailoverStatus,
+	selectedClusters sets.Set[string], standbyClusters []string, readyClusters sets.Set[string], now time.Time) (*status.GenericFailoverStatus, time.Duration) {
+	inactive := &status.GenericFailoverStatus{}
+	if policy == nil {
+		return inactive, 0
+	}
+
+	var candidates []string
+	for _, clusterName := range standbyClusters {
+		if !selectedClusters.Has(clusterName) {
+			candidates = append(candidates, clusterName)
+		}
+	}
+
+	readySelected := int64(selectedClusters.Intersection(readyClusters).Len())
+	if readySelected < policy.MinReadyClusters {
+		needed := policy.MinReadyClusters - readySelected
+
+		var previousClusters []string
+		activationTime := now.UTC().Format(time.RFC3339)
+		if previous.IsActive() {
+			previousClusters = previous.Clusters
+			activationTime = previous.ActivationTime
+		}
+		previouslyUsed := sets.New[string](previousClusters...)
+
+		var failoverClusters []string
+		for _, preferPrevious := range []bool{true, false} {
+			for _, clusterName := range candidates {
+				if int64(len(failoverClusters)) >= needed {
+					break
+				}
+				if previouslyUsed.Has(clusterName) == preferPrevious && readyClusters.Has(clusterName) {
+					failoverClusters = append(failoverClusters, clusterName)
+				}
+			}
+		}
+		if len(failoverClusters) == 0 {
+			return inactive, 0
+		}
+		return &status.GenericFailoverStatus{
+			Clusters:       failoverClusters,
+			ActivationTime: activationTime,
+		}, 0
+	}
+
+	if !previous.IsActive() {
+		return inactive, 0
+	}
+
+	recoveryTime := now
+	if previous.RecoveryTime != "" {
+		if t, err := time.Parse(time.RFC3339, previous.RecoveryTime); err == nil {
+			recoveryTime = t
+		}
+	}
+	remaining := policy.RevertGracePeriod() - now.Sub(recoveryTime)
+	if remaining <= 0 {
+		return inactive, 0
+	}
+
+	// Keep using the standby clusters that are still eligible until
+	// the grace period has elapsed.
+	eligible := sets.New[string](candidates...)
+	var failoverClusters []string
+	for _, clusterName := range previous.Clusters {
+		if eligible.Has(clusterName) {
+			failoverClusters = append(failoverClusters, clusterName)
+		}
+	}
+	if len(failoverClusters) == 0 {
+		return inactive, 0
+	}
+	return &status.GenericFailoverStatus{
+		Clusters:       failoverClusters,
+		ActivationTime: previous.ActivationTime,
+		RecoveryTime:   recoveryTime.UTC().Format(time.RFC3339),
+	}, remaining
+}
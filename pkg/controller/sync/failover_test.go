@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+func TestComputeFailover(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	timestamp := func(d time.Duration) string {
+		return now.Add(d).Format(time.RFC3339)
+	}
+	policy := &utils.GenericFailoverPolicy{
+		MinReadyClusters:         2,
+		RevertGracePeriodSeconds: ptr.To[int64](600),
+	}
+	selected := sets.New[string]("cluster1", "cluster2")
+	standby := []string{"cluster2", "standby1", "standby2"}
+
+	testCases := map[string]struct {
+		policy         *utils.GenericFailoverPolicy
+		previous       *status.GenericFailoverStatus
+		ready          sets.Set[string]
+		expectedStatus *status.GenericFailoverStatus
+		expectedDelay  time.Duration
+	}{
+		"no policy": {
+			ready:          sets.New[string]("cluster1"),
+			expectedStatus: &status.GenericFailoverStatus{},
+		},
+		"enough selected clusters ready": {
+			policy:         policy,
+			ready:          sets.New[string]("cluster1", "cluster2", "standby1"),
+			expectedStatus: &status.GenericFailoverStatus{},
+		},
+		"standby clusters used in order of preference": {
+			policy: policy,
+			ready:  sets.New[string]("standby1", "standby2"),
+			expectedStatus: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1", "standby2"},
+				ActivationTime: timestamp(0),
+			},
+		},
+		"only as many standby clusters as needed": {
+			policy: policy,
+			ready:  sets.New[string]("cluster1", "standby1", "standby2"),
+			expectedStatus: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(0),
+			},
+		},
+		"standby clusters already in use are preferred": {
+			policy: policy,
+			previous: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby2"},
+				ActivationTime: timestamp(-time.Hour),
+			},
+			ready: sets.New[string]("cluster1", "standby1", "standby2"),
+			expectedStatus: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby2"},
+				ActivationTime: timestamp(-time.Hour),
+			},
+		},
+		"unready standby clusters are not used": {
+			policy:         policy,
+			ready:          sets.New[string]("cluster1"),
+			expectedStatus: &status.GenericFailoverStatus{},
+		},
+		"recovery starts the grace period": {
+			policy: policy,
+			previous: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(-time.Hour),
+			},
+			ready: sets.New[string]("cluster1", "cluster2", "standby1"),
+			expectedStatus: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(-time.Hour),
+				RecoveryTime:   timestamp(0),
+			},
+			expectedDelay: 10 * time.Minute,
+		},
+		"standby clusters kept during the grace period": {
+			policy: policy,
+			previous: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(-time.Hour),
+				RecoveryTime:   timestamp(-4 * time.Minute),
+			},
+			ready: sets.New[string]("cluster1", "cluster2"),
+			expectedStatus: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(-time.Hour),
+				RecoveryTime:   timestamp(-4 * time.Minute),
+			},
+			expectedDelay: 6 * time.Minute,
+		},
+		"revert after the grace period": {
+			policy: policy,
+			previous: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(-time.Hour),
+				RecoveryTime:   timestamp(-10 * time.Minute),
+			},
+			ready:          sets.New[string]("cluster1", "cluster2", "standby1"),
+			expectedStatus: &status.GenericFailoverStatus{},
+		},
+		"renewed failure resets recovery": {
+			policy: policy,
+			previous: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(-time.Hour),
+				RecoveryTime:   timestamp(-4 * time.Minute),
+			},
+			ready: sets.New[string]("cluster1", "standby1"),
+			expectedStatus: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(-time.Hour),
+			},
+		},
+		"removed policy reverts immediately": {
+			previous: &status.GenericFailoverStatus{
+				Clusters:       []string{"standby1"},
+				ActivationTime: timestamp(-time.Hour),
+			},
+			ready:          sets.New[string]("standby1"),
+			expectedStatus: &status.GenericFailoverStatus{},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			failoverStatus, delay := computeFailover(testCase.policy, testCase.previous, selected, standby, testCase.ready, now)
+			if !reflect.DeepEqual(failoverStatus, testCase.expectedStatus) {
+				t.Errorf("Expected status %#v, got %#v", testCase.expectedStatus, failoverStatus)
+			}
+			if delay != testCase.expectedDelay {
+				t.Errorf("Expected delay %v, got %v", testCase.expectedDelay, delay)
+			}
+		})
+	}
+}
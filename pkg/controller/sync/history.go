@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/history"
+)
+
+// revisionOutcome is the result of checking the health of the current
+// revision of a federated resource.
+type revisionOutcome int
+
+const (
+	// revisionPending indicates that the revision is not yet healthy
+	// in all selected clusters but may still become so.
+	revisionPending revisionOutcome = iota
+	// revisionHealthy indicates that the revision is updated and
+	// healthy in all selected clusters.
+	revisionHealthy
+	// revisionFailed indicates that the revision did not become
+	// healthy within the failure timeout of the rollback policy.
+	revisionFailed
+)
+
+// ensureHistory records the current revision of the template and
+// overrides of the given resource, and rolls the resource back to its
+// last healthy revision if its rollback policy determines that the
+// current revision has failed.  Returns true if the resource was rolled
+// back, in which case it should not be propagated until the rolled back
+// form is reconciled.
+func (s *KubeFedSyncController) ensureHistory(fedResource FederatedResource, clusters []*fedv1b1.KubeFedCluster, selectedClusterNames sets.Set[string]) (bool, error) {
+	obj := fedResource.Object()
+	spec, err := history.GetHistorySpec(obj)
+	if err != nil {
+		return false, err
+	}
+	limit := spec.Limit()
+	if limit <= 0 {
+		return false, nil
+	}
+
+	templateVersion, err := fedResource.TemplateVersion()
+	if err != nil {
+		return false, err
+	}
+	overrideVersion, err := fedResource.OverrideVersion()
+	if err != nil {
+		return false, err
+	}
+
+	ctx := context.TODO()
+	now := time.Now()
+	namespace := history.Namespace(obj, s.kubeFedNamespace)
+	revisions, err := history.ListCached(s.revisionLister, obj, namespace)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list revisions")
+	}
+
+	name := history.RevisionName(obj, templateVersion, overrideVersion)
+	var current *appsv1.ControllerRevision
+	var latestNumber int64
+	for _, revision := range revisions {
+		if revision.Name == name {
+			current = revision
+		}
+		if revision.Revision > latestNumber {
+			latestNumber = revision.Revision
+		}
+	}
+
+	if current != nil && current.Annotations == nil {
+		current.Annotations = map[string]string{}
+	}
+	switch {
+	case current == nil:
+		current, err = history.NewRevision(obj, namespace, templateVersion, overrideVersion, latestNumber+1, now)
+		if err != nil {
+			return false, err
+		}
+		err = s.hostClusterClient.Create(ctx, current)
+		if apierrors.IsAlreadyExists(err) {
+			// The revision has been created but is not yet cached.
+			s.worker.EnqueueWithDelay(fedResource.FederatedName(), s.smallDelay)
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to create revision %q", current.Name)
+		}
+		revisions = append(revisions, current)
+		err = history.Prune(ctx, s.hostClusterClient, revisions, limit)
+		if err != nil {
+			return false, err
+		}
+	case current.Revision != latestNumber:
+		// A previous revision has been restored and becomes the
+		// latest revision.  A revision that previously failed is
+		// given another chance to become healthy.
+		current.Revision = latestNumber + 1
+		current.Annotations[history.ActivationTimeAnnotation] = now.UTC().Format(time.RFC3339)
+		delete(current.Annotations, history.FailedAnnotation)
+		delete(current.Annotations, history.UnhealthyClustersAnnotation)
+		err = s.hostClusterClient.Update(ctx, current)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to update revision %q", current.Name)
+		}
+	}
+
+	// A revision that was healthy once is never rolled back.
+	if history.IsHealthy(current) {
+		return false, nil
+	}
+
+	rolloutClusters, err := s.rolloutClusters(fedResource, clusters, selectedClusterNames, nil)
+	if err != nil {
+		return false, err
+	}
+	outcome, delay, unhealthyTimes := checkRevision(spec.RollbackPolicy, history.UnhealthyTimes(current), rolloutClusters, now)
+	unhealthyTimesChanged := history.SetUnhealthyTimes(current, unhealthyTimes)
+	switch outcome {
+	case revisionHealthy:
+		current.Annotations[history.HealthyAnnotation] = "true"
+		err = s.hostClusterClient.Update(ctx, current)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to update revision %q", current.Name)
+		}
+	case revisionPending:
+		if unhealthyTimesChanged {
+			err = s.hostClusterClient.Update(ctx, current)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to update revision %q", current.Name)
+			}
+		}
+		if delay > 0 {
+			s.worker.EnqueueWithDelay(fedResource.FederatedName(), delay)
+		}
+	case revisionFailed:
+		target := history.LastHealthy(revisions, current)
+		if target == nil {
+			if history.IsFailed(current) {
+				return false, nil
+			}
+			fedResource.RecordError("RolloutFailed", errors.Errorf("Revision %d did not become healthy within %s and no healthy revision is available to roll back to",
+				current.Revision, spec.RollbackPolicy.FailureTimeout()))
+		} else {
+			rolledBackObj := obj.DeepCopy()
+			err = history.Restore(rolledBackObj, target)
+			if err != nil {
+				return false, err
+			}
+			err = s.hostClusterClient.Update(ctx, rolledBackObj)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to roll back to revision %d", target.Revision)
+			}
+			fedResource.RecordEvent("RolledBack", "Revision %d did not become healthy within %s, rolled back to revision %d",
+				current.Revision, spec.RollbackPolicy.FailureTimeout(), target.Revision)
+		}
+		current.Annotations[history.FailedAnnotation] = "true"
+		err = s.hostClusterClient.Update(ctx, current)
+		if err != nil {
+			return target != nil, errors.Wrapf(err, "failed to update revision %q", current.Name)
+		}
+		return target != nil, nil
+	}
+	return false, nil
+}
+
+// checkRevision determines whether the current revision of a federated
+// resource is healthy or has failed given the rollout state of its
+// selected clusters.  A revision fails once a cluster it was propagated
+// to has been unhealthy for longer than the failure timeout of the
+// policy.  Clusters the revision has not been propagated to yet, e.g.
+// because they are held back by a rollout strategy, dependencies or
+// rate limits, do not cause a failure.  A pending revision is returned
+// with the delay until it will have failed, or zero if it cannot fail.
+// The times at which the clusters the revision was propagated to were
+// first observed to be unhealthy are returned to be recorded for the
+// next check.
+func checkRevision(policy *history.GenericRollbackPolicy, unhealthyTimes map[string]time.Time, clusters []rolloutCluster, now time.Time) (revisionOutcome, time.Duration, map[string]time.Time) {
+	if len(clusters) == 0 {
+		// Health cannot be determined without ready clusters.
+		return revisionPending, 0, unhealthyTimes
+	}
+	healthy := true
+	updatedUnhealthyTimes := map[string]time.Time{}
+	for _, cluster := range clusters {
+		if cluster.updated && cluster.healthy {
+			continue
+		}
+		healthy = false
+		if !cluster.updated {
+			continue
+		}
+		unhealthyTime, ok := unhealthyTimes[cluster.name]
+		if !ok {
+			unhealthyTime = now
+		}
+		updatedUnhealthyTimes[cluster.name] = unhealthyTime
+	}
+	if healthy {
+		return revisionHealthy, 0, nil
+	}
+	if policy == nil {
+		return revisionPending, 0, nil
+	}
+	if len(updatedUnhealthyTimes) == 0 {
+		return revisionPending, 0, nil
+	}
+	var earliest time.Time
+	for _, unhealthyTime := range updatedUnhealthyTimes {
+		if earliest.IsZero() || unhealthyTime.Before(earliest) {
+			earliest = unhealthyTime
+		}
+	}
+	deadline := earliest.Add(policy.FailureTimeout())
+	if now.Before(deadline) {
+		return revisionPending, deadline.Sub(now), updatedUnhealthyTimes
+	}
+	return revisionFailed, 0, updatedUnhealthyTimes
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history records the revisions of the template and overrides
+// of federated resources as ControllerRevisions so that a federated
+// resource can be rolled back to a previous revision.
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/rand"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+const (
+	// FederatedKindLabel identifies the kind of the federated resource
+	// a revision belongs to.
+	FederatedKindLabel = "kubefed.io/federated-kind"
+
+	TemplateVersionAnnotation = "kubefed.io/template-version"
+	OverrideVersionAnnotation = "kubefed.io/override-version"
+	// ActivationTimeAnnotation records when a revision last became the
+	// current revision of its federated resource.
+	ActivationTimeAnnotation = "kubefed.io/activation-time"
+	// HealthyAnnotation marks a revision that was propagated to all
+	// selected clusters and reported healthy by all of them.
+	HealthyAnnotation = "kubefed.io/healthy"
+	// FailedAnnotation marks a revision that was rolled back because
+	// it did not become healthy in time.
+	FailedAnnotation = "kubefed.io/failed"
+	// UnhealthyClustersAnnotation records when each of the clusters
+	// the revision was propagated to was first observed to be
+	// unhealthy.
+	UnhealthyClustersAnnotation = "kubefed.io/unhealthy-clusters"
+
+	// DefaultRevisionHistoryLimit is the number of revisions kept for
+	// a federated resource with a rollback policy that does not
+	// specify a revision history limit.
+	DefaultRevisionHistoryLimit = 10
+
+	// maxNameLength is the maximum length of the name of a revision.
+	maxNameLength = 253
+)
+
+// GenericRollbackPolicy determines when a federated resource is rolled
+// back to its last healthy revision.
+type GenericRollbackPolicy struct {
+	// FailureTimeoutSeconds is how long the current revision may be
+	// unhealthy in any selected cluster before it is rolled back.
+	FailureTimeoutSeconds int64 `json:"failureTimeoutSeconds"`
+}
+
+// FailureTimeout returns the failure timeout of the policy.
+func (p *GenericRollbackPolicy) FailureTimeout() time.Duration {
+	return time.Duration(p.FailureTimeoutSeconds) * time.Second
+}
+
+type GenericHistorySpec struct {
+	RevisionHistoryLimit *int32                 `json:"revisionHistoryLimit,omitempty"`
+	RollbackPolicy       *GenericRollbackPolicy `json:"rollbackPolicy,omitempty"`
+}
+
+type GenericHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GenericHistorySpec `json:"spec,omitempty"`
+}
+
+// GetHistorySpec returns the revision history configuration of the
+// given federated resource.
+func GetHistorySpec(fedObject *unstructured.Unstructured) (*GenericHistorySpec, error) {
+	history := &GenericHistory{}
+	err := utils.UnstructuredToInterface(fedObject, history)
+	if err != nil {
+		return nil, err
+	}
+	return &history.Spec, nil
+}
+
+// Limit returns the number of revisions to keep.  Revisions are only
+// kept for federated resources that specify a revision history limit
+// or a rollback policy.
+func (s *GenericHistorySpec) Limit() int {
+	if s.RevisionHistoryLimit != nil {
+		return int(*s.RevisionHistoryLimit)
+	}
+	if s.RollbackPolicy != nil {
+		return DefaultRevisionHistoryLimit
+	}
+	return 0
+}
+
+// Namespace returns the namespace of the revisions of the given
+// federated resource.  Revisions of cluster-scoped federated resources
+// are kept in the KubeFed system namespace.
+func Namespace(fedObject *unstructured.Unstructured, kubeFedNamespace string) string {
+	if fedObject.GetNamespace() == "" {
+		return kubeFedNamespace
+	}
+	return fedObject.GetNamespace()
+}
+
+// RevisionName returns the name of the revision of the given federated
+// resource with the given template and override versions.
+func RevisionName(fedObject *unstructured.Unstructured, templateVersion, overrideVersion string) string {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(templateVersion + "/" + overrideVersion))
+	suffix := rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+	prefix := fedObject.GetName()
+	if len(prefix) > maxNameLength-len(suffix)-1 {
+		prefix = prefix[:maxNameLength-len(suffix)-1]
+	}
+	return fmt.Sprintf("%s-%s", prefix, suffix)
+}
+
+// NewInformer returns an informer for the revisions of federated
+// resources of the given kind in the given namespace.
+func NewInformer(client kubeclientset.Interface, namespace, federatedKind string) cache.SharedIndexInformer {
+	return appsinformers.NewFilteredControllerRevisionInformer(client, namespace, utils.NoResyncPeriod, cache.Indexers{},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = labels.SelectorFromSet(labels.Set{FederatedKindLabel: federatedKind}).String()
+		})
+}
+
+// List returns the revisions of the given federated resource ordered
+// by revision number.
+func List(ctx context.Context, client generic.Client, fedObject *unstructured.Unstructured, namespace string) ([]*appsv1.ControllerRevision, error) {
+	revisionList := &appsv1.ControllerRevisionList{}
+	err := client.List(ctx, revisionList, namespace, runtimeclient.MatchingLabels{FederatedKindLabel: fedObject.GetKind()})
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]*appsv1.ControllerRevision, 0, len(revisionList.Items))
+	for i := range revisionList.Items {
+		revisions = append(revisions, &revisionList.Items[i])
+	}
+	return controlledRevisions(revisions, fedObject), nil
+}
+
+// ListCached returns copies of the cached revisions of the given
+// federated resource ordered by revision number.
+func ListCached(lister appslisters.ControllerRevisionLister, fedObject *unstructured.Unstructured, namespace string) ([]*appsv1.ControllerRevision, error) {
+	cachedRevisions, err := lister.ControllerRevisions(namespace).List(labels.SelectorFromSet(labels.Set{FederatedKindLabel: fedObject.GetKind()}))
+	if err != nil {
+		return nil, err
+	}
+	// Cached revisions must not be modified.
+	revisions := controlledRevisions(cachedRevisions, fedObject)
+	for i, revision := range revisions {
+		revisions[i] = revision.DeepCopy()
+	}
+	return revisions, nil
+}
+
+func controlledRevisions(revisions []*appsv1.ControllerRevision, fedObject *unstructured.Unstructured) []*appsv1.ControllerRevision {
+	var controlled []*appsv1.ControllerRevision
+	for _, revision := range revisions {
+		if metav1.IsControlledBy(revision, fedObject) {
+			controlled = append(controlled, revision)
+		}
+	}
+	sort.Slice(controlled, func(i, j int) bool {
+		return controlled[i].Revision < controlled[j].Revision
+	})
+	return controlled
+}
+
+// NewRevision returns a revision that records the template and
+// overrides of the given federated resource.
+func NewRevision(fedObject *unstructured.Unstructured, namespace, templateVersion, overrideVersion string, revisionNumber int64, now time.Time) (*appsv1.ControllerRevision, error) {
+	spec := map[string]interface{}{}
+	for _, field := range []string{utils.TemplateField, utils.OverridesField} {
+		value, ok, err := unstructured.NestedFieldCopy(fedObject.Object, utils.SpecField, field)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve spec.%s", field)
+		}
+		if ok {
+			spec[field] = value
+		}
+	}
+	data, err := json.Marshal(map[string]interface{}{utils.SpecField: spec})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the revision")
+	}
+
+	return &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RevisionName(fedObject, templateVersion, overrideVersion),
+			Namespace: namespace,
+			Labels: map[string]string{
+				FederatedKindLabel: fedObject.GetKind(),
+			},
+			Annotations: map[string]string{
+				TemplateVersionAnnotation: templateVersion,
+				OverrideVersionAnnotation: overrideVersion,
+				ActivationTimeAnnotation:  now.UTC().Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         fedObject.GetAPIVersion(),
+					Kind:               fedObject.GetKind(),
+					Name:               fedObject.GetName(),
+					UID:                fedObject.GetUID(),
+					Controller:         ptr.To(true),
+					BlockOwnerDeletion: ptr.To(false),
+				},
+			},
+		},
+		Data:     runtime.RawExtension{Raw: data},
+		Revision: revisionNumber,
+	}, nil
+}
+
+// Restore sets the template and overrides of the given federated
+// resource to those recorded by the given revision.
+func Restore(fedObject *unstructured.Unstructured, revision *appsv1.ControllerRevision) error {
+	recorded := map[string]interface{}{}
+	if err := utiljson.Unmarshal(revision.Data.Raw, &recorded); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal revision %q", revision.Name)
+	}
+	for _, field := range []string{utils.TemplateField, utils.OverridesField} {
+		value, ok, err := unstructured.NestedFieldCopy(recorded, utils.SpecField, field)
+		if err != nil {
+			return errors.Wrapf(err, "failed to retrieve spec.%s of revision %q", field, revision.Name)
+		}
+		if !ok {
+			unstructured.RemoveNestedField(fedObject.Object, utils.SpecField, field)
+			continue
+		}
+		if err := unstructured.SetNestedField(fedObject.Object, value, utils.SpecField, field); err != nil {
+			return errors.Wrapf(err, "failed to set spec.%s", field)
+		}
+	}
+	return nil
+}
+
+// Matches returns whether the template and overrides of the given
+// federated resource are those recorded by the given revision.
+func Matches(fedObject *unstructured.Unstructured, revision *appsv1.ControllerRevision) (bool, error) {
+	restored := fedObject.DeepCopy()
+	if err := Restore(restored, revision); err != nil {
+		return false, err
+	}
+	return equality.Semantic.DeepEqual(restored.Object, fedObject.Object), nil
+}
+
+// ActivationTime returns the time at which the given revision last
+// became current.
+func ActivationTime(revision *appsv1.ControllerRevision) time.Time {
+	activationTime, err := time.Parse(time.RFC3339, revision.Annotations[ActivationTimeAnnotation])
+	if err != nil {
+		return revision.CreationTimestamp.Time
+	}
+	return activationTime
+}
+
+// UnhealthyTimes returns when each of the clusters the given revision
+// was propagated to was first observed to be unhealthy.
+func UnhealthyTimes(revision *appsv1.ControllerRevision) map[string]time.Time {
+	unhealthyTimes := map[string]time.Time{}
+	value, ok := revision.Annotations[UnhealthyClustersAnnotation]
+	if !ok {
+		return unhealthyTimes
+	}
+	recorded := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &recorded); err != nil {
+		return unhealthyTimes
+	}
+	for clusterName, timestamp := range recorded {
+		if unhealthyTime, err := time.Parse(time.RFC3339, timestamp); err == nil {
+			unhealthyTimes[clusterName] = unhealthyTime
+		}
+	}
+	return unhealthyTimes
+}
+
+// SetUnhealthyTimes records when each of the clusters the given
+// revision was propagated to was first observed to be unhealthy, and
+// returns whether the recorded times changed.
+func SetUnhealthyTimes(revision *appsv1.ControllerRevision, unhealthyTimes map[string]time.Time) bool {
+	previous, hadTimes := revision.Annotations[UnhealthyClustersAnnotation]
+	if len(unhealthyTimes) == 0 {
+		delete(revision.Annotations, UnhealthyClustersAnnotation)
+		return hadTimes
+	}
+	recorded := make(map[string]string, len(unhealthyTimes))
+	for clusterName, unhealthyTime := range unhealthyTimes {
+		recorded[clusterName] = unhealthyTime.UTC().Format(time.RFC3339)
+	}
+	// Keys of the marshaled map are sorted, so the value is stable.
+	value, _ := json.Marshal(recorded)
+	if revision.Annotations == nil {
+		revision.Annotations = map[string]string{}
+	}
+	revision.Annotations[UnhealthyClustersAnnotation] = string(value)
+	return !hadTimes || previous != string(value)
+}
+
+// IsHealthy returns whether the given revision was reported healthy by
+// all selected clusters.
+func IsHealthy(revision *appsv1.ControllerRevision) bool {
+	return revision.Annotations[HealthyAnnotation] == "true"
+}
+
+// IsFailed returns whether the given revision was rolled back.
+func IsFailed(revision *appsv1.ControllerRevision) bool {
+	return revision.Annotations[FailedAnnotation] == "true"
+}
+
+// LastHealthy returns the most recent healthy revision other than the
+// given current revision, or nil if there is none.
+func LastHealthy(revisions []*appsv1.ControllerRevision, current *appsv1.ControllerRevision) *appsv1.ControllerRevision {
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+		if revision.Name != current.Name && IsHealthy(revision) && !IsFailed(revision) {
+			return revision
+		}
+	}
+	return nil
+}
+
+// Prune deletes the oldest of the given revisions, which must be
+// ordered by revision number, so that no more than the given number of
+// revisions remain.
+func Prune(ctx context.Context, client generic.Client, revisions []*appsv1.ControllerRevision, limit int) error {
+	for i := 0; i < len(revisions)-limit; i++ {
+		revision := revisions[i]
+		err := client.Delete(ctx, revision, revision.Namespace, revision.Name)
+		if runtimeclient.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "failed to delete revision %q", revision.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newFedObject(replicas int64, overrides bool) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"template": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+			},
+		},
+		"placement": map[string]interface{}{
+			"clusterSelector": map[string]interface{}{},
+		},
+	}
+	if overrides {
+		spec["overrides"] = []interface{}{
+			map[string]interface{}{
+				"clusterName": "cluster1",
+			},
+		}
+	}
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	obj.SetAPIVersion("types.kubefed.io/v1beta1")
+	obj.SetKind("FederatedDeployment")
+	obj.SetNamespace("ns")
+	obj.SetName("app")
+	obj.SetUID("uid")
+	return obj
+}
+
+func TestRestore(t *testing.T) {
+	original := newFedObject(1, true)
+	revision, err := NewRevision(original, "ns", "tv", "ov", 1, time.Now())
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if !metav1.IsControlledBy(revision, original) {
+		t.Fatalf("Expected the revision to be controlled by the federated resource")
+	}
+
+	matches, err := Matches(original, revision)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if !matches {
+		t.Fatalf("Expected the revision to match the federated resource it was recorded from")
+	}
+
+	updated := newFedObject(3, false)
+	matches, err = Matches(updated, revision)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if matches {
+		t.Fatalf("Expected the revision not to match the updated federated resource")
+	}
+
+	err = Restore(updated, revision)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	matches, err = Matches(updated, revision)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if !matches {
+		t.Fatalf("Expected the restored federated resource to match the revision")
+	}
+	if _, ok, _ := unstructured.NestedMap(updated.Object, "spec", "placement"); !ok {
+		t.Fatalf("Expected the placement of the federated resource not to be restored")
+	}
+}
+
+func TestRevisionName(t *testing.T) {
+	obj := newFedObject(1, false)
+	name := RevisionName(obj, "tv", "ov")
+	if name != RevisionName(obj, "tv", "ov") {
+		t.Fatalf("Expected the revision name to be stable")
+	}
+	if name == RevisionName(obj, "tv", "ov2") {
+		t.Fatalf("Expected revisions with different versions to have different names")
+	}
+}
+
+func TestLastHealthy(t *testing.T) {
+	newRevision := func(name string, annotations map[string]string) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+		}
+	}
+	healthy := map[string]string{HealthyAnnotation: "true"}
+	failed := map[string]string{HealthyAnnotation: "true", FailedAnnotation: "true"}
+
+	testCases := map[string]struct {
+		revisions []*appsv1.ControllerRevision
+		expected  string
+	}{
+		"most recent healthy revision": {
+			revisions: []*appsv1.ControllerRevision{
+				newRevision("r1", healthy),
+				newRevision("r2", healthy),
+				newRevision("r3", nil),
+				newRevision("current", nil),
+			},
+			expected: "r2",
+		},
+		"failed revisions are skipped": {
+			revisions: []*appsv1.ControllerRevision{
+				newRevision("r1", healthy),
+				newRevision("r2", failed),
+				newRevision("current", nil),
+			},
+			expected: "r1",
+		},
+		"current revision is skipped": {
+			revisions: []*appsv1.ControllerRevision{
+				newRevision("current", healthy),
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			current := testCase.revisions[len(testCase.revisions)-1]
+			revision := LastHealthy(testCase.revisions, current)
+			name := ""
+			if revision != nil {
+				name = revision.Name
+			}
+			if name != testCase.expected {
+				t.Fatalf("Expected revision %q, got %q", testCase.expected, name)
+			}
+		})
+	}
+}
+
+func TestUnhealthyTimes(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	revision := &appsv1.ControllerRevision{}
+	unhealthyTimes := map[string]time.Time{"cluster1": now, "cluster2": now.Add(-time.Minute)}
+
+	if !SetUnhealthyTimes(revision, unhealthyTimes) {
+		t.Fatalf("Expected recording unhealthy times to change the revision")
+	}
+	if SetUnhealthyTimes(revision, unhealthyTimes) {
+		t.Fatalf("Expected recording the same unhealthy times not to change the revision")
+	}
+	recorded := UnhealthyTimes(revision)
+	if len(recorded) != len(unhealthyTimes) {
+		t.Fatalf("Expected unhealthy times %v, got %v", unhealthyTimes, recorded)
+	}
+	for clusterName, unhealthyTime := range unhealthyTimes {
+		if !recorded[clusterName].Equal(unhealthyTime) {
+			t.Fatalf("Expected unhealthy times %v, got %v", unhealthyTimes, recorded)
+		}
+	}
+
+	if !SetUnhealthyTimes(revision, nil) {
+		t.Fatalf("Expected clearing unhealthy times to change the revision")
+	}
+	if _, ok := revision.Annotations[UnhealthyClustersAnnotation]; ok {
+		t.Fatalf("Expected the unhealthy times to be removed")
+	}
+}
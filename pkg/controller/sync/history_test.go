@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kubefed/pkg/controller/sync/history"
+)
+
+func TestCheckRevision(t *testing.T) {
+	now := time.Now()
+	policy := &history.GenericRollbackPolicy{FailureTimeoutSeconds: 60}
+
+	testCases := map[string]struct {
+		policy                 *history.GenericRollbackPolicy
+		unhealthyTimes         map[string]time.Time
+		clusters               []rolloutCluster
+		expectedOutcome        revisionOutcome
+		expectedDelay          time.Duration
+		expectedUnhealthyTimes map[string]time.Time
+	}{
+		"healthy in all clusters": {
+			policy:         policy,
+			unhealthyTimes: map[string]time.Time{"cluster2": now.Add(-time.Hour)},
+			clusters: []rolloutCluster{
+				{name: "cluster1", updated: true, healthy: true},
+				{name: "cluster2", updated: true, healthy: true},
+			},
+			expectedOutcome: revisionHealthy,
+		},
+		"becomes unhealthy": {
+			policy: policy,
+			clusters: []rolloutCluster{
+				{name: "cluster1", updated: true, healthy: true},
+				{name: "cluster2", updated: true},
+			},
+			expectedOutcome:        revisionPending,
+			expectedDelay:          60 * time.Second,
+			expectedUnhealthyTimes: map[string]time.Time{"cluster2": now},
+		},
+		"unhealthy within the failure timeout": {
+			policy:         policy,
+			unhealthyTimes: map[string]time.Time{"cluster2": now.Add(-20 * time.Second)},
+			clusters: []rolloutCluster{
+				{name: "cluster1", updated: true, healthy: true},
+				{name: "cluster2", updated: true},
+			},
+			expectedOutcome:        revisionPending,
+			expectedDelay:          40 * time.Second,
+			expectedUnhealthyTimes: map[string]time.Time{"cluster2": now.Add(-20 * time.Second)},
+		},
+		"unhealthy after the failure timeout": {
+			policy:         policy,
+			unhealthyTimes: map[string]time.Time{"cluster2": now.Add(-time.Hour)},
+			clusters: []rolloutCluster{
+				{name: "cluster1", updated: true, healthy: true},
+				{name: "cluster2", updated: true},
+			},
+			expectedOutcome:        revisionFailed,
+			expectedUnhealthyTimes: map[string]time.Time{"cluster2": now.Add(-time.Hour)},
+		},
+		"recovered cluster is no longer timed": {
+			policy:         policy,
+			unhealthyTimes: map[string]time.Time{"cluster1": now.Add(-time.Hour)},
+			clusters: []rolloutCluster{
+				{name: "cluster1", updated: true, healthy: true},
+				{name: "cluster2", updated: true},
+			},
+			expectedOutcome:        revisionPending,
+			expectedDelay:          60 * time.Second,
+			expectedUnhealthyTimes: map[string]time.Time{"cluster2": now},
+		},
+		"not yet updated clusters do not fail": {
+			policy:         policy,
+			unhealthyTimes: map[string]time.Time{"cluster2": now.Add(-time.Hour)},
+			clusters: []rolloutCluster{
+				{name: "cluster1", updated: true, healthy: true},
+				{name: "cluster2", wave: 1},
+			},
+			expectedOutcome: revisionPending,
+		},
+		"no rollback policy": {
+			clusters: []rolloutCluster{
+				{name: "cluster1", updated: true},
+			},
+			expectedOutcome: revisionPending,
+		},
+		"no ready clusters": {
+			policy:                 policy,
+			unhealthyTimes:         map[string]time.Time{"cluster1": now.Add(-time.Hour)},
+			expectedOutcome:        revisionPending,
+			expectedUnhealthyTimes: map[string]time.Time{"cluster1": now.Add(-time.Hour)},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			outcome, delay, unhealthyTimes := checkRevision(testCase.policy, testCase.unhealthyTimes, testCase.clusters, now)
+			if outcome != testCase.expectedOutcome {
+				t.Fatalf("Expected outcome %d, got %d", testCase.expectedOutcome, outcome)
+			}
+			if delay != testCase.expectedDelay {
+				t.Fatalf("Expected delay %v, got %v", testCase.expectedDelay, delay)
+			}
+			if len(unhealthyTimes) != len(testCase.expectedUnhealthyTimes) {
+				t.Fatalf("Expected unhealthy times %v, got %v", testCase.expectedUnhealthyTimes, unhealthyTimes)
+			}
+			for clusterName, expectedTime := range testCase.expectedUnhealthyTimes {
+				if !unhealthyTimes[clusterName].Equal(expectedTime) {
+					t.Fatalf("Expected unhealthy times %v, got %v", testCase.expectedUnhealthyTimes, unhealthyTimes)
+				}
+			}
+		})
+	}
+}
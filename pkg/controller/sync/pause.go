@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+// pausedClusterStatus determines the status to report for a cluster
+// when propagation of a federated resource is paused, given the object
+// in the cluster (nil if it does not exist), whether the cluster is
+// selected for placement and the version recorded for the cluster.
+// The returned boolean is false if no status should be reported for
+// the cluster.
+//
+// A cluster is only reported as propagated if the object in the
+// cluster is unchanged since it was last propagated from the current
+// form of the federated resource.
+func pausedClusterStatus(clusterObj *unstructured.Unstructured, selected bool, recordedVersion string) (status.PropagationStatus, bool) {
+	switch {
+	case clusterObj == nil:
+		return status.Paused, selected
+	case !selected && clusterObj.GetDeletionTimestamp() != nil:
+		return status.WaitingForRemoval, true
+	case selected && recordedVersion != "" && recordedVersion == utils.ObjectVersion(clusterObj):
+		return status.ClusterPropagationOK, true
+	}
+	return status.Paused, true
+}
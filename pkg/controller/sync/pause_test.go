@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+)
+
+func TestPausedClusterStatus(t *testing.T) {
+	clusterObj := &unstructured.Unstructured{}
+	clusterObj.SetGeneration(2)
+	deletedObj := clusterObj.DeepCopy()
+	now := metav1.Now()
+	deletedObj.SetDeletionTimestamp(&now)
+
+	testCases := map[string]struct {
+		clusterObj      *unstructured.Unstructured
+		selected        bool
+		recordedVersion string
+		expectedStatus  status.PropagationStatus
+		expectedOK      bool
+	}{
+		"selected cluster up to date": {
+			clusterObj:      clusterObj,
+			selected:        true,
+			recordedVersion: "gen:2",
+			expectedStatus:  status.ClusterPropagationOK,
+			expectedOK:      true,
+		},
+		"selected cluster changed": {
+			clusterObj:      clusterObj,
+			selected:        true,
+			recordedVersion: "gen:1",
+			expectedStatus:  status.Paused,
+			expectedOK:      true,
+		},
+		"selected cluster not propagated from current form": {
+			clusterObj:     clusterObj,
+			selected:       true,
+			expectedStatus: status.Paused,
+			expectedOK:     true,
+		},
+		"selected cluster without object": {
+			selected:       true,
+			expectedStatus: status.Paused,
+			expectedOK:     true,
+		},
+		"unselected cluster with object": {
+			clusterObj:      clusterObj,
+			recordedVersion: "gen:2",
+			expectedStatus:  status.Paused,
+			expectedOK:      true,
+		},
+		"unselected cluster with object being deleted": {
+			clusterObj:     deletedObj,
+			expectedStatus: status.WaitingForRemoval,
+			expectedOK:     true,
+		},
+		"unselected cluster without object": {
+			expectedStatus: status.Paused,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			propStatus, ok := pausedClusterStatus(testCase.clusterObj, testCase.selected, testCase.recordedVersion)
+			if ok != testCase.expectedOK {
+				t.Fatalf("Expected reported %v, got %v", testCase.expectedOK, ok)
+			}
+			if ok && propStatus != testCase.expectedStatus {
+				t.Fatalf("Expected status %q, got %q", testCase.expectedStatus, propStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+// RenderForCluster returns the object that the sync controller would
+// propagate to the named member cluster for the given federated
+// object.  The object is computed from the template of the federated
+// object, the fields retained from the given cluster object and the
+// overrides for the cluster, in the same way as by the dispatcher.  If
+// the cluster object is nil, the object is rendered as it would be
+// created.  For a type propagated with server-side apply, the
+// rendered object is the applied object, which leaves metadata set in
+// the member cluster to other field managers.  The given clusters are
+// used to resolve overrides for cluster selectors and templated
+// override values.  Placement is not evaluated, and no request is
+// made to any cluster.
+func RenderForCluster(typeConfig typeconfig.Interface, fedObject *unstructured.Unstructured,
+	clusters []*fedv1b1.KubeFedCluster, clusterName string, clusterObj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	fedResource := &federatedResource{
+		typeConfig:        typeConfig,
+		targetIsNamespace: typeConfig.GetTargetType().Kind == utils.NamespaceKind,
+		federatedKind:     typeConfig.GetFederatedType().Kind,
+		federatedName:     utils.NewQualifiedName(fedObject),
+		federatedResource: fedObject,
+		// Events about unsupported template fields are discarded
+		// since the rendered object reflects their removal.
+		eventRecorder: &record.FakeRecorder{},
+
+		getClusters: func() ([]*fedv1b1.KubeFedCluster, error) {
+			return clusters, nil
+		},
+	}
+
+	obj, err := fedResource.ObjectForCluster(clusterName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute the object from the template")
+	}
+
+	if clusterObj != nil {
+		if utils.IsExplicitlyUnmanaged(clusterObj) {
+			return nil, errors.Errorf("the object in cluster %q has label %s: %s and is not updated",
+				clusterName, utils.ManagedByKubeFedLabelKey, utils.UnmanagedByKubeFedLabelValue)
+		}
+		if typeConfig.GetServerSideApplyEnabled() {
+			err = dispatch.RetainClusterFieldsForApply(fedResource.RetainFields(), obj, clusterObj, fedObject)
+		} else {
+			err = dispatch.RetainClusterFields(fedResource.TargetKind(), fedResource.RetainFields(), obj, clusterObj, fedObject)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retain fields")
+		}
+	}
+
+	err = fedResource.ApplyOverrides(obj, clusterName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply overrides")
+	}
+	return obj, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+	kfenable "sigs.k8s.io/kubefed/pkg/kubefedctl/enable"
+)
+
+func TestRenderForCluster(t *testing.T) {
+	typeConfig := &fedv1b1.FederatedTypeConfig{
+		Spec: fedv1b1.FederatedTypeConfigSpec{
+			TargetType: fedv1b1.APIResource{
+				Group:      "apps",
+				Version:    "v1",
+				Kind:       "Deployment",
+				PluralName: "deployments",
+				Scope:      "Namespaced",
+			},
+			FederatedType: fedv1b1.APIResource{
+				Group:      "types.kubefed.io",
+				Version:    "v1beta1",
+				Kind:       "FederatedDeployment",
+				PluralName: "federateddeployments",
+				Scope:      "Namespaced",
+			},
+		},
+	}
+	fedObject := &unstructured.Unstructured{}
+	fedYAML := `
+apiVersion: types.kubefed.io/v1beta1
+kind: FederatedDeployment
+metadata:
+  name: foo
+  namespace: bar
+spec:
+  template:
+    spec:
+      replicas: 1
+  overrides:
+  - clusterSelector:
+      matchLabels:
+        env: prod
+    clusterOverrides:
+    - path: /spec/replicas
+      value: 3
+`
+	err := kfenable.DecodeYAML(strings.NewReader(fedYAML), fedObject)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	clusters := []*fedv1b1.KubeFedCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "cluster1",
+				Labels: map[string]string{"env": "prod"},
+			},
+		},
+	}
+
+	renderedObj, err := RenderForCluster(typeConfig, fedObject, clusters, "cluster1", nil)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if renderedObj.GetAPIVersion() != "apps/v1" || renderedObj.GetKind() != "Deployment" {
+		t.Fatalf("Expected apps/v1 Deployment, got %s %s", renderedObj.GetAPIVersion(), renderedObj.GetKind())
+	}
+	if renderedObj.GetNamespace() != "bar" || renderedObj.GetName() != "foo" {
+		t.Fatalf("Expected bar/foo, got %s/%s", renderedObj.GetNamespace(), renderedObj.GetName())
+	}
+	replicas, _, _ := unstructured.NestedInt64(renderedObj.Object, "spec", "replicas")
+	if replicas != 3 {
+		t.Fatalf("Expected the override of 3 replicas, got %v", replicas)
+	}
+	if !utils.HasManagedLabel(renderedObj) {
+		t.Fatalf("Expected the managed label")
+	}
+
+	clusterObj := renderedObj.DeepCopy()
+	clusterObj.SetResourceVersion("42")
+	clusterObj.SetAnnotations(map[string]string{"foo": "bar"})
+	renderedObj, err = RenderForCluster(typeConfig, fedObject, clusters, "cluster1", clusterObj)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if renderedObj.GetResourceVersion() != "42" {
+		t.Fatalf("Expected the resource version of the cluster object, got %q", renderedObj.GetResourceVersion())
+	}
+	if !reflect.DeepEqual(renderedObj.GetAnnotations(), clusterObj.GetAnnotations()) {
+		t.Fatalf("Expected the annotations of the cluster object, got %v", renderedObj.GetAnnotations())
+	}
+
+	// An apply without retained fields is not conditioned on the
+	// resource version and leaves annotations and finalizers set in
+	// the member cluster to other field managers.
+	applyStrategy := fedv1b1.ApplyStrategyServerSideApply
+	typeConfig.Spec.ApplyStrategy = &applyStrategy
+	clusterObj.SetFinalizers([]string{"foo"})
+	renderedObj, err = RenderForCluster(typeConfig, fedObject, clusters, "cluster1", clusterObj)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if renderedObj.GetResourceVersion() != "" {
+		t.Fatalf("Expected no resource version for server-side apply, got %q", renderedObj.GetResourceVersion())
+	}
+	if len(renderedObj.GetAnnotations()) != 0 || len(renderedObj.GetFinalizers()) != 0 {
+		t.Fatalf("Expected no retained metadata for server-side apply, got annotations %v and finalizers %v",
+			renderedObj.GetAnnotations(), renderedObj.GetFinalizers())
+	}
+
+	clusterObj.SetLabels(map[string]string{utils.ManagedByKubeFedLabelKey: utils.UnmanagedByKubeFedLabelValue})
+	if _, err := RenderForCluster(typeConfig, fedObject, clusters, "cluster1", clusterObj); err == nil {
+		t.Fatalf("Expected an error for an unmanaged cluster object")
+	}
+}
@@ -31,15 +31,21 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1a1 "sigs.k8s.io/kubefed/pkg/apis/core/v1alpha1"
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/version"
 	"sigs.k8s.io/kubefed/pkg/controller/utils"
 )
 
+// errPlacementPolicyNotFound indicates that the PlacementPolicy
+// referenced by a federated resource could not be found.
+var errPlacementPolicyNotFound = errors.New("placement policy not found")
+
 // FederatedResource encapsulates the behavior of a logical federated
 // resource which may be implemented by one or more kubernetes
 // resources in the cluster hosting the KubeFed control plane.
@@ -48,10 +54,16 @@ type FederatedResource interface {
 
 	FederatedName() utils.QualifiedName
 	FederatedKind() string
+	TemplateVersion() (string, error)
+	OverrideVersion() (string, error)
 	UpdateVersions(selectedClusters []string, versionMap map[string]string) error
 	DeleteVersions()
 	ComputePlacement(clusters []*fedv1b1.KubeFedCluster) (selectedClusters sets.Set[string], err error)
+	ComputeRetainedPlacement(clusters []*fedv1b1.KubeFedCluster, existingClusters sets.Set[string]) (retainedClusters sets.Set[string], err error)
+	ComputeStandbyPlacement(clusters []*fedv1b1.KubeFedCluster) (standbyClusters []string, err error)
+	FailoverPolicy() (*utils.GenericFailoverPolicy, error)
 	NamespaceNotFederated() bool
+	IsPaused() bool
 }
 
 type federatedResource struct {
@@ -70,6 +82,20 @@ type federatedResource struct {
 	namespace         *unstructured.Unstructured
 	fedNamespace      *unstructured.Unstructured
 	eventRecorder     record.EventRecorder
+
+	// kubeFedNamespace is the KubeFed system namespace, which
+	// contains the placement policies of cluster-scoped resources.
+	kubeFedNamespace     string
+	placementPolicyStore cache.Store
+
+	// inheritNamespacePlacement indicates whether resources without
+	// placement inherit the placement of their federated namespace
+	// unless annotated otherwise.
+	inheritNamespacePlacement bool
+
+	// getClusters returns the member clusters whose labels determine
+	// the overrides of cluster selectors.
+	getClusters func() ([]*fedv1b1.KubeFedCluster, error)
 }
 
 func (r *federatedResource) FederatedName() utils.QualifiedName {
@@ -88,6 +114,10 @@ func (r *federatedResource) TargetKind() string {
 	return r.typeConfig.GetTargetType().Kind
 }
 
+func (r *federatedResource) RetainFields() []string {
+	return r.typeConfig.GetRetainFields()
+}
+
 func (r *federatedResource) TargetGVK() schema.GroupVersionKind {
 	apiResource := r.typeConfig.GetTargetType()
 	return apiResourceToGVK(&apiResource)
@@ -105,7 +135,31 @@ func (r *federatedResource) TemplateVersion() (string, error) {
 func (r *federatedResource) OverrideVersion() (string, error) {
 	// TODO(marun) Consider hashing overrides per cluster to minimize
 	// unnecessary updates.
-	return GetOverrideHash(r.federatedResource)
+	hasSelectorOverrides, err := utils.HasSelectorOverrides(r.federatedResource)
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading cluster overrides")
+	}
+	hasTemplatedOverrides, err := utils.HasTemplatedOverrides(r.federatedResource)
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading cluster overrides")
+	}
+	if !hasSelectorOverrides && !hasTemplatedOverrides {
+		return GetOverrideHash(r.federatedResource)
+	}
+	// The overrides of a cluster also depend on its metadata when
+	// cluster selectors or templated values are used, so the
+	// resolved overrides are hashed to ensure that a change in
+	// cluster metadata is propagated.
+	overridesMap, err := r.computeOverrides()
+	if err != nil {
+		return "", err
+	}
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"overrides": overridesMap,
+		},
+	}
+	return hashUnstructured(obj, "overrides")
 }
 
 func (r *federatedResource) VersionForCluster(clusterName string) (string, error) {
@@ -130,16 +184,142 @@ func (r *federatedResource) DeleteVersions() {
 }
 
 func (r *federatedResource) ComputePlacement(clusters []*fedv1b1.KubeFedCluster) (sets.Set[string], error) {
+	resource, fedNamespace, err := r.placementObjects()
+	if err != nil {
+		return nil, err
+	}
 	if r.typeConfig.GetNamespaced() {
-		return utils.ComputeNamespacedPlacement(r.federatedResource, r.fedNamespace, clusters, r.limitedScope, false)
+		return utils.ComputeNamespacedPlacement(resource, fedNamespace, clusters, r.limitedScope, false)
 	}
-	return utils.ComputePlacement(r.federatedResource, clusters, false)
+	return utils.ComputePlacement(resource, clusters, false)
+}
+
+// placementObjects returns the federated resource and its federated
+// namespace with the placement of any referenced PlacementPolicy
+// applied.  A resource without placement is given the placement of
+// its federated namespace if it inherits namespace placement.
+func (r *federatedResource) placementObjects() (resource, fedNamespace *unstructured.Unstructured, err error) {
+	resource, err = r.resolvePlacement(r.federatedResource)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.fedNamespace != nil {
+		fedNamespace, err = r.resolvePlacement(r.fedNamespace)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if !r.targetIsNamespace && !utils.HasPlacement(resource) &&
+		utils.InheritsNamespacePlacement(resource, fedNamespace, r.inheritNamespacePlacement) {
+		resource, err = utils.InheritNamespacePlacement(resource, fedNamespace)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return resource, fedNamespace, nil
+}
+
+// resolvePlacement returns the given federated object with its
+// placement replaced by the placement of the PlacementPolicy it
+// references.  An object without a reference is returned verbatim.
+// Placement cannot be computed if the referenced policy does not
+// exist, which avoids removing the resource from all clusters.
+func (r *federatedResource) resolvePlacement(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	policyName, err := utils.GetPlacementRef(obj)
+	if err != nil || policyName == "" {
+		return obj, err
+	}
+	qualifiedName := utils.PlacementPolicyName(obj, policyName, r.kubeFedNamespace)
+	if r.placementPolicyStore == nil {
+		return nil, errors.Wrapf(errPlacementPolicyNotFound, "PlacementPolicy %q cannot be resolved", qualifiedName)
+	}
+	rawPolicy, exists, err := r.placementPolicyStore.GetByKey(qualifiedName.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve PlacementPolicy %q", qualifiedName)
+	}
+	if !exists {
+		return nil, errors.Wrapf(errPlacementPolicyNotFound, "PlacementPolicy %q", qualifiedName)
+	}
+	return utils.ApplyPlacementPolicy(obj, rawPolicy.(*fedv1a1.PlacementPolicy))
+}
+
+// FailoverPolicy returns the failover policy of the effective
+// placement of the resource, or nil if none is specified.
+func (r *federatedResource) FailoverPolicy() (*utils.GenericFailoverPolicy, error) {
+	resource, _, err := r.placementObjects()
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetFailoverPolicy(resource)
+}
+
+// ComputeRetainedPlacement determines the clusters in which the
+// resource may continue to exist.  This is a superset of the
+// selected clusters that also includes the given clusters already
+// holding the resource that are excluded from placement only by
+// NoSchedule taints, within the bounds of the spread constraints of
+// the resource.
+func (r *federatedResource) ComputeRetainedPlacement(clusters []*fedv1b1.KubeFedCluster, existingClusters sets.Set[string]) (sets.Set[string], error) {
+	selectedClusters, err := r.ComputePlacement(clusters)
+	if err != nil {
+		return nil, err
+	}
+	toleratedClusters, err := r.ComputePlacement(utils.WithoutNoScheduleTaints(clusters))
+	if err != nil {
+		return nil, err
+	}
+	resource, _, err := r.placementObjects()
+	if err != nil {
+		return nil, err
+	}
+	return utils.RetainPlacement(resource, selectedClusters, toleratedClusters.Intersection(existingClusters), clusters)
+}
+
+// ComputeStandbyPlacement determines the standby clusters of the
+// failover policy of the resource in order of preference.  Standby
+// clusters of a namespaced resource are limited to the placement of
+// its federated namespace.
+func (r *federatedResource) ComputeStandbyPlacement(clusters []*fedv1b1.KubeFedCluster) ([]string, error) {
+	resource, fedNamespace, err := r.placementObjects()
+	if err != nil {
+		return nil, err
+	}
+	standbyClusters, err := utils.ComputeStandbyPlacement(resource, clusters)
+	if err != nil {
+		return nil, err
+	}
+	if !r.typeConfig.GetNamespaced() {
+		return standbyClusters, nil
+	}
+	if fedNamespace == nil {
+		if r.limitedScope {
+			return standbyClusters, nil
+		}
+		return nil, nil
+	}
+	namespaceClusters, err := utils.ComputePlacement(fedNamespace, clusters, false)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, clusterName := range standbyClusters {
+		if namespaceClusters.Has(clusterName) {
+			result = append(result, clusterName)
+		}
+	}
+	return result, nil
 }
 
 func (r *federatedResource) NamespaceNotFederated() bool {
 	return r.typeConfig.GetNamespaced() && r.fedNamespace == nil
 }
 
+// IsPaused returns whether propagation of the resource is paused,
+// either directly or by its federated namespace.
+func (r *federatedResource) IsPaused() bool {
+	return utils.IsPaused(r.federatedResource) || utils.IsPaused(r.fedNamespace)
+}
+
 func (r *federatedResource) IsNamespaceInHostCluster(clusterObj runtimeclient.Object) bool {
 	// TODO(marun) This comment should be added to the documentation
 	// and removed from this function (where it is no longer
@@ -218,7 +398,7 @@ func (r *federatedResource) ApplyOverrides(obj *unstructured.Unstructured, clust
 		return err
 	}
 	if overrides != nil {
-		if err := utils.ApplyJSONPatch(obj, overrides); err != nil {
+		if err := utils.ApplyClusterOverrides(obj, overrides); err != nil {
 			return err
 		}
 	}
@@ -241,16 +421,42 @@ func (r *federatedResource) RecordEvent(reason, messageFmt string, args ...inter
 }
 
 func (r *federatedResource) overridesForCluster(clusterName string) (utils.ClusterOverrides, error) {
+	overridesMap, err := r.resolveOverrides()
+	if err != nil {
+		return nil, err
+	}
+	return overridesMap[clusterName], nil
+}
+
+// resolveOverrides returns the overrides for each member cluster,
+// accounting for both cluster names and cluster selectors.
+func (r *federatedResource) resolveOverrides() (utils.OverridesMap, error) {
 	r.Lock()
 	defer r.Unlock()
 	if r.overridesMap == nil {
-		overridesMap, err := utils.GetOverrides(r.federatedResource)
+		overridesMap, err := r.computeOverrides()
 		if err != nil {
-			return nil, errors.Wrapf(err, "Error reading cluster overrides")
+			return nil, err
 		}
 		r.overridesMap = overridesMap
 	}
-	return r.overridesMap[clusterName], nil
+	return r.overridesMap, nil
+}
+
+func (r *federatedResource) computeOverrides() (utils.OverridesMap, error) {
+	var clusters []*fedv1b1.KubeFedCluster
+	if r.getClusters != nil {
+		var err error
+		clusters, err = r.getClusters()
+		if err != nil {
+			return nil, errors.Wrap(err, "Error retrieving clusters to resolve overrides")
+		}
+	}
+	overridesMap, err := utils.ResolveOverrides(r.federatedResource, clusters)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading cluster overrides")
+	}
+	return overridesMap, nil
 }
 
 func GetTemplateHash(fieldMap map[string]interface{}) (string, error) {
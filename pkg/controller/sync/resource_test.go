@@ -17,11 +17,16 @@ limitations under the License.
 package sync
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
 
+	fedv1a1 "sigs.k8s.io/kubefed/pkg/apis/core/v1alpha1"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
 	kfenable "sigs.k8s.io/kubefed/pkg/kubefedctl/enable"
 )
 
@@ -47,3 +52,91 @@ spec:
 		t.Fatalf("Expected %s, got %s", expectedHash, hash)
 	}
 }
+
+func TestResolvePlacement(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, namespace := range []string{"ns1", "kube-federation-system"} {
+		err := store.Add(&fedv1a1.PlacementPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "policy",
+			},
+			Spec: fedv1a1.PlacementPolicySpec{
+				Placement: fedv1a1.Placement{
+					Clusters: []fedv1a1.ClusterReference{{Name: namespace}},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("An unexpected error occurred: %v", err)
+		}
+	}
+	r := &federatedResource{
+		kubeFedNamespace:     "kube-federation-system",
+		placementPolicyStore: store,
+	}
+
+	testCases := map[string]struct {
+		namespace        string
+		placementRef     string
+		expectedClusters []string
+		expectedErr      bool
+	}{
+		"placement used verbatim without reference": {
+			namespace:        "ns1",
+			expectedClusters: []string{"own"},
+		},
+		"namespaced resource references policy in its namespace": {
+			namespace:        "ns1",
+			placementRef:     "policy",
+			expectedClusters: []string{"ns1"},
+		},
+		"cluster-scoped resource references policy in kubefed namespace": {
+			placementRef:     "policy",
+			expectedClusters: []string{"kube-federation-system"},
+		},
+		"missing policy is an error": {
+			namespace:    "ns2",
+			placementRef: "policy",
+			expectedErr:  true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": make(map[string]interface{}),
+				},
+			}
+			obj.SetNamespace(testCase.namespace)
+			obj.SetName("resource")
+			if err := utils.SetClusterNames(obj, []string{"own"}); err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			if testCase.placementRef != "" {
+				if err := unstructured.SetNestedField(obj.Object, testCase.placementRef, utils.SpecField, utils.PlacementRefField, utils.NameField); err != nil {
+					t.Fatalf("An unexpected error occurred: %v", err)
+				}
+			}
+
+			resolved, err := r.resolvePlacement(obj)
+			if testCase.expectedErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			clusterNames, err := utils.GetClusterNames(resolved)
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			if !reflect.DeepEqual(clusterNames, testCase.expectedClusters) {
+				t.Fatalf("Expected clusters %v, got %v", testCase.expectedClusters, clusterNames)
+			}
+		})
+	}
+}
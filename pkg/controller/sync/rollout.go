@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+// rolloutCluster is the state of a selected cluster in the rollout of
+// a federated resource.
+type rolloutCluster struct {
+	name string
+	wave int
+	// updated indicates that the object in the cluster has been
+	// propagated from the current form of the federated resource.
+	updated bool
+	// healthy indicates that the status of the updated object shows
+	// that it has been fully rolled out in the cluster.
+	healthy bool
+}
+
+// ensureRollout determines the selected clusters to which propagation
+// of the given resource is held back by its rollout strategy, and the
+// progress of the rollout.  If the resource has no rollout strategy, no
+// clusters are held back and the returned progress removes the Rollout
+// condition.
+func (s *KubeFedSyncController) ensureRollout(fedResource FederatedResource, clusters []*fedv1b1.KubeFedCluster, selectedClusterNames sets.Set[string]) (sets.Set[string], *status.RolloutProgress, error) {
+	strategy, err := utils.GetRolloutStrategy(fedResource.Object())
+	if err != nil {
+		return nil, nil, err
+	}
+	if strategy == nil {
+		return nil, &status.RolloutProgress{}, nil
+	}
+
+	rolloutClusters, err := s.rolloutClusters(fedResource, clusters, selectedClusterNames, strategy)
+	if err != nil {
+		return nil, nil, err
+	}
+	heldClusterNames, progress := computeRollout(strategy, rolloutClusters)
+	return heldClusterNames, progress, nil
+}
+
+// rolloutClusters returns the rollout state of the given selected
+// clusters that are ready.  Clusters are assigned to waves by the given
+// strategy, or all to the first wave if the strategy is nil.
+func (s *KubeFedSyncController) rolloutClusters(fedResource FederatedResource, clusters []*fedv1b1.KubeFedCluster, selectedClusterNames sets.Set[string], strategy *utils.GenericRolloutStrategy) ([]rolloutCluster, error) {
+	key := fedResource.TargetName().String()
+	var rolloutClusters []rolloutCluster
+	for _, cluster := range clusters {
+		// Clusters that are not ready cannot be updated and do not
+		// hold back the rollout.
+		if !selectedClusterNames.Has(cluster.Name) || !utils.IsClusterReady(&cluster.Status) {
+			continue
+		}
+		wave := 0
+		if strategy != nil {
+			var err error
+			wave, err = strategy.WaveForCluster(cluster)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rawClusterObj, _, err := s.informer.GetTargetStore().GetByKey(cluster.Name, key)
+		if err != nil {
+			return nil, err
+		}
+		// A recorded version indicates that the cluster object was
+		// propagated from the current template and overrides.
+		version, err := fedResource.VersionForCluster(cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+		rc := rolloutCluster{name: cluster.Name, wave: wave}
+		if rawClusterObj != nil && version != "" {
+			clusterObj := rawClusterObj.(*unstructured.Unstructured)
+			rc.updated = clusterObj.GetDeletionTimestamp() == nil
+			rc.healthy = utils.IsObjectHealthy(clusterObj)
+		}
+		rolloutClusters = append(rolloutClusters, rc)
+	}
+	return rolloutClusters, nil
+}
+
+// computeRollout determines the clusters to which propagation of the
+// current form of a federated resource must be held back according to
+// its rollout strategy, and describes the progress of the rollout.
+//
+// Only the clusters of the earliest wave that is not yet updated and
+// healthy are updated, and no more clusters are updated than would
+// make the number of updated but unhealthy clusters exceed the
+// maximum number of unavailable clusters.
+func computeRollout(strategy *utils.GenericRolloutStrategy, clusters []rolloutCluster) (sets.Set[string], *status.RolloutProgress) {
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].wave != clusters[j].wave {
+			return clusters[i].wave < clusters[j].wave
+		}
+		return clusters[i].name < clusters[j].name
+	})
+
+	waves := sets.New[int]()
+	currentWave := -1
+	completed := 0
+	var unhealthy []string
+	for _, cluster := range clusters {
+		waves.Insert(cluster.wave)
+		if cluster.updated && cluster.healthy {
+			completed++
+			continue
+		}
+		if cluster.updated {
+			unhealthy = append(unhealthy, cluster.name)
+		}
+		if currentWave < 0 {
+			currentWave = cluster.wave
+		}
+	}
+
+	if completed == len(clusters) {
+		return nil, &status.RolloutProgress{
+			Complete: true,
+			Message:  fmt.Sprintf("%d of %d clusters updated and healthy", completed, len(clusters)),
+		}
+	}
+
+	budget := len(clusters)
+	if strategy.MaxUnavailableClusters != nil {
+		budget = int(*strategy.MaxUnavailableClusters) - len(unhealthy)
+	}
+	heldClusters := sets.New[string]()
+	for _, cluster := range clusters {
+		if cluster.updated {
+			continue
+		}
+		if cluster.wave == currentWave && budget > 0 {
+			budget--
+			continue
+		}
+		heldClusters.Insert(cluster.name)
+	}
+
+	message := fmt.Sprintf("%d of %d clusters updated and healthy", completed, len(clusters))
+	if waves.Len() > 1 {
+		waveNumber := sort.SearchInts(sets.List(waves), currentWave) + 1
+		message += fmt.Sprintf(", rolling out wave %d of %d", waveNumber, waves.Len())
+	}
+	if len(unhealthy) > 0 {
+		message += fmt.Sprintf(", waiting for %s to become healthy", strings.Join(unhealthy, ", "))
+	}
+	return heldClusters, &status.RolloutProgress{Message: message}
+}
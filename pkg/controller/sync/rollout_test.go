@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+func TestComputeRollout(t *testing.T) {
+	waves := &utils.GenericRolloutStrategy{
+		Waves: []utils.GenericRolloutWave{{}, {}},
+	}
+	maxUnavailable := &utils.GenericRolloutStrategy{
+		MaxUnavailableClusters: ptr.To[int64](1),
+	}
+
+	testCases := map[string]struct {
+		strategy         *utils.GenericRolloutStrategy
+		clusters         []rolloutCluster
+		expectedHeld     sets.Set[string]
+		expectedProgress *status.RolloutProgress
+	}{
+		"rollout complete": {
+			strategy: waves,
+			clusters: []rolloutCluster{
+				{name: "cluster1", wave: 0, updated: true, healthy: true},
+				{name: "cluster2", wave: 1, updated: true, healthy: true},
+			},
+			expectedProgress: &status.RolloutProgress{
+				Complete: true,
+				Message:  "2 of 2 clusters updated and healthy",
+			},
+		},
+		"first wave updated": {
+			strategy: waves,
+			clusters: []rolloutCluster{
+				{name: "cluster1", wave: 0},
+				{name: "cluster2", wave: 0},
+				{name: "cluster3", wave: 1},
+			},
+			expectedHeld: sets.New[string]("cluster3"),
+			expectedProgress: &status.RolloutProgress{
+				Message: "0 of 3 clusters updated and healthy, rolling out wave 1 of 2",
+			},
+		},
+		"next wave waits for unhealthy clusters": {
+			strategy: waves,
+			clusters: []rolloutCluster{
+				{name: "cluster1", wave: 0, updated: true, healthy: true},
+				{name: "cluster2", wave: 0, updated: true},
+				{name: "cluster3", wave: 1},
+			},
+			expectedHeld: sets.New[string]("cluster3"),
+			expectedProgress: &status.RolloutProgress{
+				Message: "1 of 3 clusters updated and healthy, rolling out wave 1 of 2, waiting for cluster2 to become healthy",
+			},
+		},
+		"next wave started once healthy": {
+			strategy: waves,
+			clusters: []rolloutCluster{
+				{name: "cluster3", wave: 2},
+				{name: "cluster1", wave: 0, updated: true, healthy: true},
+				{name: "cluster2", wave: 1},
+			},
+			expectedHeld: sets.New[string]("cluster3"),
+			expectedProgress: &status.RolloutProgress{
+				Message: "1 of 3 clusters updated and healthy, rolling out wave 2 of 3",
+			},
+		},
+		"max unavailable clusters updated at once": {
+			strategy: maxUnavailable,
+			clusters: []rolloutCluster{
+				{name: "cluster1"},
+				{name: "cluster2"},
+				{name: "cluster3"},
+			},
+			expectedHeld: sets.New[string]("cluster2", "cluster3"),
+			expectedProgress: &status.RolloutProgress{
+				Message: "0 of 3 clusters updated and healthy",
+			},
+		},
+		"unhealthy clusters count as unavailable": {
+			strategy: maxUnavailable,
+			clusters: []rolloutCluster{
+				{name: "cluster1", updated: true},
+				{name: "cluster2"},
+			},
+			expectedHeld: sets.New[string]("cluster2"),
+			expectedProgress: &status.RolloutProgress{
+				Message: "0 of 2 clusters updated and healthy, waiting for cluster1 to become healthy",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			held, progress := computeRollout(testCase.strategy, testCase.clusters)
+			if held.Len() != testCase.expectedHeld.Len() || !held.IsSuperset(testCase.expectedHeld) {
+				t.Fatalf("Expected held clusters %v, got %v", sets.List(testCase.expectedHeld), sets.List(held))
+			}
+			if !reflect.DeepEqual(progress, testCase.expectedProgress) {
+				t.Fatalf("Expected progress %v, got %v", testCase.expectedProgress, progress)
+			}
+		})
+	}
+}
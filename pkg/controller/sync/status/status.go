@@ -40,6 +40,14 @@ type ConditionType string
 const (
 	ClusterPropagationOK PropagationStatus = ""
 	WaitingForRemoval    PropagationStatus = "WaitingForRemoval"
+	WaitingForRollout    PropagationStatus = "WaitingForRollout"
+	// WaitingForDependencies indicates that creation of the resource
+	// in a cluster is held back until the federated resources it
+	// depends on have been propagated to the cluster.
+	WaitingForDependencies PropagationStatus = "WaitingForDependencies"
+	// Paused indicates that the resource in a cluster may differ from
+	// its federated resource because propagation is paused.
+	Paused PropagationStatus = "Paused"
 
 	// Cluster-specific errors
 	ClusterNotReady        PropagationStatus = "ClusterNotReady"
@@ -57,6 +65,21 @@ const (
 	ClientRetrievalFailed  PropagationStatus = "ClientRetrievalFailed"
 	ManagedLabelFalse      PropagationStatus = "ManagedLabelFalse"
 
+	// DependencyRetrievalFailed indicates that the federated resources
+	// the resource depends on could not be retrieved to determine
+	// whether it can be created in a cluster.
+	DependencyRetrievalFailed PropagationStatus = "DependencyRetrievalFailed"
+
+	// Drifted indicates that the resource in a cluster was changed
+	// and the change was not reverted due to the drift policy of the
+	// federated resource.
+	Drifted PropagationStatus = "Drifted"
+
+	// RateLimited indicates that an operation on the resource in a
+	// cluster was held back by the propagation rate limit of the
+	// cluster for longer than the operation timeout.
+	RateLimited PropagationStatus = "RateLimited"
+
 	// Operation timeout errors
 	CreationTimedOut     PropagationStatus = "CreationTimedOut"
 	UpdateTimedOut       PropagationStatus = "UpdateTimedOut"
@@ -68,14 +91,21 @@ const (
 	ComputePlacementFailed AggregateReason = "ComputePlacementFailed"
 	CheckClusters          AggregateReason = "CheckClusters"
 	NamespaceNotFederated  AggregateReason = "NamespaceNotFederated"
+	ComputeRolloutFailed   AggregateReason = "ComputeRolloutFailed"
+	RolloutInProgress      AggregateReason = "RolloutInProgress"
+	PropagationPaused      AggregateReason = "PropagationPaused"
 
 	PropagationConditionType ConditionType = "Propagation"
+	RolloutConditionType     ConditionType = "Rollout"
 )
 
 type GenericClusterStatus struct {
 	Name         string            `json:"name"`
 	Status       PropagationStatus `json:"status,omitempty"`
 	RemoteStatus interface{}       `json:"remoteStatus,omitempty"`
+	// Paths of the fields of a drifted resource that differ from the
+	// desired state.
+	DriftedFields []string `json:"driftedFields,omitempty"`
 }
 
 type GenericCondition struct {
@@ -93,12 +123,36 @@ type GenericCondition struct {
 	// (brief) reason for the condition's last transition.
 	// +optional
 	Reason AggregateReason `json:"reason,omitempty"`
+	// Human-readable message indicating details about the condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GenericFailoverStatus records the standby clusters used because
+// too few of the clusters selected for placement were ready.
+type GenericFailoverStatus struct {
+	// Standby clusters in use in addition to the selected clusters.
+	Clusters []string `json:"clusters,omitempty"`
+	// Time at which standby clusters started to be used.
+	// +optional
+	ActivationTime string `json:"activationTime,omitempty"`
+	// Time at which enough selected clusters were ready again.
+	// Standby clusters stop being used once the revert grace period
+	// has elapsed since this time.
+	// +optional
+	RecoveryTime string `json:"recoveryTime,omitempty"`
+}
+
+// IsActive returns whether standby clusters are in use.
+func (s *GenericFailoverStatus) IsActive() bool {
+	return s != nil && len(s.Clusters) > 0
 }
 
 type GenericFederatedStatus struct {
 	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
 	Conditions         []*GenericCondition    `json:"conditions,omitempty"`
 	Clusters           []GenericClusterStatus `json:"clusters,omitempty"`
+	Failover           *GenericFailoverStatus `json:"failover,omitempty"`
 }
 
 type GenericFederatedResource struct {
@@ -113,6 +167,26 @@ type PropagationStatusMap map[string]PropagationStatus
 type CollectedPropagationStatus struct {
 	StatusMap        PropagationStatusMap
 	ResourcesUpdated bool
+	// DriftedFieldsMap holds the differing fields of the resources
+	// in clusters with a Drifted status.
+	DriftedFieldsMap map[string][]string
+	// Failover is the failover decision made for the resource. A nil
+	// value leaves the recorded failover status unchanged, and an
+	// inactive one clears it.
+	Failover *GenericFailoverStatus
+	// Rollout is the progress of the rollout of the resource. A nil
+	// value leaves the Rollout condition unchanged, and one without a
+	// message removes it.
+	Rollout *RolloutProgress
+}
+
+// RolloutProgress describes the progress of propagating a change to a
+// federated resource with a rollout strategy.
+type RolloutProgress struct {
+	// Complete indicates that all selected clusters are updated and
+	// healthy.
+	Complete bool
+	Message  string
 }
 
 type CollectedResourceStatus struct {
@@ -164,6 +238,20 @@ func SetFederatedStatus(fedObject *unstructured.Unstructured, reason AggregateRe
 	return true, nil
 }
 
+// GetFailoverStatus returns the failover status recorded for the
+// federated resource, or nil if standby clusters are not in use.
+func GetFailoverStatus(fedObject *unstructured.Unstructured) (*GenericFailoverStatus, error) {
+	resource := &GenericFederatedResource{}
+	err := utils.UnstructuredToInterface(fedObject, resource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to unmarshall to generic resource")
+	}
+	if resource.Status == nil || !resource.Status.Failover.IsActive() {
+		return nil, nil
+	}
+	return resource.Status.Failover, nil
+}
+
 // IsRecoverableError returns whether the given PropagationStatus is a possibly recoverable error.
 func IsRecoverableError(status PropagationStatus) bool {
 	switch status {
@@ -206,7 +294,7 @@ func (s *GenericFederatedStatus) update(generation int64, reason AggregateReason
 		}
 	}
 
-	clustersChanged := s.setClusters(collectedStatus.StatusMap, collectedResourceStatus.StatusMap, resourceStatusCollection)
+	clustersChanged := s.setClusters(collectedStatus.StatusMap, collectedStatus.DriftedFieldsMap, collectedResourceStatus.StatusMap, resourceStatusCollection)
 
 	// Indicate that changes were propagated if either status.clusters
 	// was changed or if existing resources were updated (which could
@@ -216,7 +304,11 @@ func (s *GenericFederatedStatus) update(generation int64, reason AggregateReason
 
 	propStatusUpdated := s.setPropagationCondition(reason, changesPropagated)
 
-	statusUpdated := generationUpdated || propStatusUpdated
+	failoverUpdated := s.setFailover(collectedStatus.Failover)
+
+	rolloutUpdated := s.setRolloutCondition(collectedStatus.Rollout)
+
+	statusUpdated := generationUpdated || propStatusUpdated || failoverUpdated || rolloutUpdated
 
 	klog.V(4).Infof("Value of flags: propStatusUpdated: '%v'; statusUpdated '%v'; changesPropagated '%v'", propStatusUpdated, statusUpdated, changesPropagated)
 	return statusUpdated
@@ -225,8 +317,8 @@ func (s *GenericFederatedStatus) update(generation int64, reason AggregateReason
 // setClusters sets the status.clusters slice from propagation and resource status
 // maps. Returns a boolean indication of whether the status.clusters was
 // modified.
-func (s *GenericFederatedStatus) setClusters(statusMap PropagationStatusMap, resourceStatusMap map[string]interface{}, resourceStatusCollection bool) bool {
-	if !s.clustersDiffer(statusMap, resourceStatusMap, resourceStatusCollection) {
+func (s *GenericFederatedStatus) setClusters(statusMap PropagationStatusMap, driftedFieldsMap map[string][]string, resourceStatusMap map[string]interface{}, resourceStatusCollection bool) bool {
+	if !s.clustersDiffer(statusMap, driftedFieldsMap, resourceStatusMap, resourceStatusCollection) {
 		return false
 	}
 	s.Clusters = []GenericClusterStatus{}
@@ -236,6 +328,8 @@ func (s *GenericFederatedStatus) setClusters(statusMap PropagationStatusMap, res
 			Name:         clusterName,
 			Status:       status,
 			RemoteStatus: rawResourceStatus,
+
+			DriftedFields: driftedFieldsMap[clusterName],
 		})
 	}
 	return true
@@ -243,7 +337,7 @@ func (s *GenericFederatedStatus) setClusters(statusMap PropagationStatusMap, res
 
 // clustersDiffer checks whether `status.clusters` differs from the
 // given status map.
-func (s *GenericFederatedStatus) clustersDiffer(statusMap PropagationStatusMap, resourceStatusMap map[string]interface{}, resourceStatusCollection bool) bool {
+func (s *GenericFederatedStatus) clustersDiffer(statusMap PropagationStatusMap, driftedFieldsMap map[string][]string, resourceStatusMap map[string]interface{}, resourceStatusCollection bool) bool {
 	if len(s.Clusters) != len(statusMap) || resourceStatusCollection && len(s.Clusters) != len(resourceStatusMap) {
 		klog.V(4).Infof("Clusters differs from the size: clusters = %v, statusMap = %v, resourceStatusMap = %v", s.Clusters, statusMap, resourceStatusMap)
 		return true
@@ -252,6 +346,9 @@ func (s *GenericFederatedStatus) clustersDiffer(statusMap PropagationStatusMap,
 		if statusMap[status.Name] != status.Status {
 			return true
 		}
+		if !reflect.DeepEqual(driftedFieldsMap[status.Name], status.DriftedFields) {
+			return true
+		}
 		if !reflect.DeepEqual(resourceStatusMap[status.Name], status.RemoteStatus) {
 			klog.V(4).Infof("Clusters resource status differ: %v VS %v", resourceStatusMap[status.Name], status.RemoteStatus)
 			return true
@@ -308,6 +405,80 @@ func (s *GenericFederatedStatus) setPropagationCondition(reason AggregateReason,
 	return updateRequired
 }
 
+// setRolloutCondition ensures that the Rollout condition reflects the
+// given rollout progress.  Returns a boolean indication of whether the
+// condition was modified.
+func (s *GenericFederatedStatus) setRolloutCondition(rollout *RolloutProgress) bool {
+	if rollout == nil {
+		return false
+	}
+
+	index := -1
+	for i, condition := range s.Conditions {
+		if condition.Type == RolloutConditionType {
+			index = i
+			break
+		}
+	}
+	if rollout.Message == "" {
+		if index < 0 {
+			return false
+		}
+		s.Conditions = append(s.Conditions[:index], s.Conditions[index+1:]...)
+		return true
+	}
+
+	newStatus := apiv1.ConditionFalse
+	reason := RolloutInProgress
+	if rollout.Complete {
+		newStatus = apiv1.ConditionTrue
+		reason = AggregateSuccess
+	}
+
+	var rolloutCondition *GenericCondition
+	if index < 0 {
+		rolloutCondition = &GenericCondition{
+			Type: RolloutConditionType,
+		}
+		s.Conditions = append(s.Conditions, rolloutCondition)
+	} else {
+		rolloutCondition = s.Conditions[index]
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	transition := index < 0 || !(rolloutCondition.Status == newStatus && rolloutCondition.Reason == reason)
+	if transition {
+		rolloutCondition.LastTransitionTime = now
+		rolloutCondition.Status = newStatus
+		rolloutCondition.Reason = reason
+	}
+
+	updateRequired := transition || rolloutCondition.Message != rollout.Message
+	if updateRequired {
+		rolloutCondition.Message = rollout.Message
+		rolloutCondition.LastUpdateTime = now
+	}
+	return updateRequired
+}
+
+// setFailover ensures that the failover status reflects the given
+// failover decision. Returns a boolean indication of whether the
+// failover status was modified.
+func (s *GenericFederatedStatus) setFailover(failover *GenericFailoverStatus) bool {
+	if failover == nil {
+		return false
+	}
+	if !failover.IsActive() {
+		failover = nil
+	}
+	if reflect.DeepEqual(s.Failover, failover) {
+		return false
+	}
+	s.Failover = failover
+	return true
+}
+
 func normalizeStatus(collectedResourceStatus CollectedResourceStatus) (*CollectedResourceStatus, error) {
 	if len(collectedResourceStatus.StatusMap) == 0 {
 		return &collectedResourceStatus, nil
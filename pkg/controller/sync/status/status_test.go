@@ -28,6 +28,7 @@ func TestGenericPropagationStatusUpdateChanged(t *testing.T) {
 		generation               int64
 		reason                   AggregateReason
 		statusMap                PropagationStatusMap
+		driftedFieldsMap         map[string][]string
 		resourceStatusMap        map[string]interface{}
 		remoteStatus             interface{}
 		resourcesUpdated         bool
@@ -180,6 +181,18 @@ func TestGenericPropagationStatusUpdateChanged(t *testing.T) {
 			resourceStatusCollection: false,
 			expectedChanged:          true,
 		},
+		"Drifted fields indicate changed": {
+			statusMap: PropagationStatusMap{
+				"cluster1": Drifted,
+			},
+			driftedFieldsMap: map[string][]string{
+				"cluster1": {"/spec/replicas"},
+			},
+			reason:                   AggregateSuccess,
+			resourcesUpdated:         false,
+			resourceStatusCollection: false,
+			expectedChanged:          true,
+		},
 		"Changed generation indicates changed with remote status collection enabled": {
 			generation:               1,
 			resourceStatusCollection: true,
@@ -210,6 +223,7 @@ func TestGenericPropagationStatusUpdateChanged(t *testing.T) {
 			collectedStatus := CollectedPropagationStatus{
 				StatusMap:        tc.statusMap,
 				ResourcesUpdated: tc.resourcesUpdated,
+				DriftedFieldsMap: tc.driftedFieldsMap,
 			}
 			collectedResourceStatus := CollectedResourceStatus{
 				StatusMap:        tc.resourceStatusMap,
@@ -280,3 +294,55 @@ func TestNormalizeStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSetFailover(t *testing.T) {
+	active := &GenericFailoverStatus{
+		Clusters:       []string{"cluster2"},
+		ActivationTime: "2024-01-01T00:00:00Z",
+	}
+	testCases := map[string]struct {
+		current          *GenericFailoverStatus
+		failover         *GenericFailoverStatus
+		expectedChanged  bool
+		expectedFailover *GenericFailoverStatus
+	}{
+		"nil leaves failover unchanged": {
+			current:          active,
+			expectedFailover: active,
+		},
+		"activation indicates changed": {
+			failover:         active,
+			expectedChanged:  true,
+			expectedFailover: active,
+		},
+		"same failover indicates unchanged": {
+			current: active,
+			failover: &GenericFailoverStatus{
+				Clusters:       []string{"cluster2"},
+				ActivationTime: "2024-01-01T00:00:00Z",
+			},
+			expectedFailover: active,
+		},
+		"inactive failover clears status": {
+			current:         active,
+			failover:        &GenericFailoverStatus{},
+			expectedChanged: true,
+		},
+		"inactive failover without status indicates unchanged": {
+			failover: &GenericFailoverStatus{},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			fedStatus := &GenericFederatedStatus{Failover: tc.current}
+			changed := fedStatus.setFailover(tc.failover)
+			if changed != tc.expectedChanged {
+				t.Errorf("Expected changed to be %v, got %v", tc.expectedChanged, changed)
+			}
+			if !reflect.DeepEqual(fedStatus.Failover, tc.expectedFailover) {
+				t.Errorf("Expected failover %#v, got %#v", tc.expectedFailover, fedStatus.Failover)
+			}
+		})
+	}
+}
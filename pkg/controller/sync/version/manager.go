@@ -40,6 +40,14 @@ import (
 	"sigs.k8s.io/kubefed/pkg/controller/utils"
 )
 
+// errStaleVersion indicates that a version was written to the API by
+// another process after it was cached.
+var errStaleVersion = errors.New("the version was written by another process")
+
+// maxStaleVersionRetries bounds the attempts to merge the versions to
+// record with versions written by other processes.
+const maxStaleVersionRetries = 3
+
 // VersionedResource defines the methods a federated resource must
 // implement to allow versions to be tracked by the VersionManager.
 type VersionedResource interface {
@@ -139,7 +147,11 @@ func (m *Manager) Get(resource VersionedResource) (map[string]string, error) {
 }
 
 // Update ensures that the propagated version for the given versioned
-// resource is recorded.
+// resource is recorded.  When reconciliation is sharded, versions are
+// written by more than one replica.  The versions written by another
+// process since they were cached are retrieved from the API and merged
+// again, so that the versions of clusters not updated by this process
+// are not overwritten with stale ones.
 func (m *Manager) Update(resource VersionedResource,
 	selectedClusters []string, versionMap map[string]string) error {
 	templateVersion, err := resource.TemplateVersion()
@@ -151,9 +163,36 @@ func (m *Manager) Update(resource VersionedResource,
 		return errors.Wrap(err, "Failed to determine override version")
 	}
 	qualifiedName := m.versionQualifiedName(resource.FederatedName())
+
+	for attempt := 1; ; attempt++ {
+		updatedVersionMap := make(map[string]string, len(versionMap))
+		for clusterName, version := range versionMap {
+			updatedVersionMap[clusterName] = version
+		}
+		obj := m.updateStatus(resource, qualifiedName, templateVersion, overrideVersion, selectedClusters, updatedVersionMap)
+		if obj == nil {
+			return nil
+		}
+
+		// Since writeVersion calls the Kube API, the manager should be
+		// unlocked to avoid blocking on calls across the network.
+		err := m.writeVersion(obj, qualifiedName)
+		if !errors.Is(err, errStaleVersion) || attempt == maxStaleVersionRetries {
+			return err
+		}
+		klog.V(4).Infof("Merging the versions of %s %q written by another process", m.adapter.TypeName(), qualifiedName)
+	}
+}
+
+// updateStatus sets the status of the cached version for the given
+// versioned resource, and returns the version to write to the API or
+// nil if no update is necessary.
+func (m *Manager) updateStatus(resource VersionedResource, qualifiedName utils.QualifiedName,
+	templateVersion, overrideVersion string, selectedClusters []string, versionMap map[string]string) runtimeclient.Object {
 	key := qualifiedName.String()
 
 	m.Lock()
+	defer m.Unlock()
 
 	obj, ok := m.versions[key]
 
@@ -177,7 +216,6 @@ func (m *Manager) Update(resource VersionedResource,
 	}
 
 	if oldStatus != nil && utils.PropagatedVersionStatusEquivalent(oldStatus, status) {
-		m.Unlock()
 		klog.V(4).Infof("No update necessary for %s %q", m.adapter.TypeName(), qualifiedName)
 		return nil
 	}
@@ -189,13 +227,7 @@ func (m *Manager) Update(resource VersionedResource,
 	} else {
 		m.adapter.SetStatus(obj, status)
 	}
-
-	m.Unlock()
-
-	// Since writeVersion calls the Kube API, the manager should be
-	// unlocked to avoid blocking on calls across the network.
-
-	return m.writeVersion(obj, qualifiedName)
+	return obj
 }
 
 // Delete removes the named propagated version from the manager.
@@ -209,6 +241,35 @@ func (m *Manager) Delete(qualifiedName utils.QualifiedName) {
 	m.Unlock()
 }
 
+// Refresh replaces the in-memory versions with the versions retrieved
+// from the API.  It should be called when the federated resources
+// reconciled by this process change, since the versions of resources
+// reconciled by another process may have been written in the meantime.
+func (m *Manager) Refresh() error {
+	versionList := m.adapter.NewListObject()
+	if err := m.client.List(context.TODO(), versionList, m.namespace); err != nil {
+		return errors.Wrapf(err, "Failed to list propagated versions for %q", m.federatedKind)
+	}
+	items, err := meta.ExtractList(versionList)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to understand list result for %q", m.adapter.TypeName())
+	}
+	typePrefix := common.PropagatedVersionPrefix(m.targetKind)
+	versions := make(map[string]runtimeclient.Object)
+	for _, obj := range items {
+		qualifiedName := utils.NewQualifiedName(obj.(runtimeclient.Object))
+		// Ignore propagated version for other types
+		if strings.HasPrefix(qualifiedName.Name, typePrefix) {
+			versions[qualifiedName.String()] = obj.(runtimeclient.Object)
+		}
+	}
+	m.Lock()
+	m.versions = versions
+	m.Unlock()
+	klog.V(4).Infof("Version manager for %q refreshed", m.federatedKind)
+	return nil
+}
+
 func (m *Manager) list(ctx context.Context) (runtimeclient.ObjectList, bool) {
 	// Attempt retrieval of list of versions until success or context is cancelled.
 	var versionList runtimeclient.ObjectList
@@ -272,7 +333,9 @@ func (m *Manager) versionQualifiedName(qualifiedName utils.QualifiedName) utils.
 }
 
 // writeVersion serializes the current state of the named propagated
-// version to the API.
+// version to the API.  If the version was written by another process
+// after it was cached, the cached version is replaced by the version
+// retrieved from the API and errStaleVersion is returned.
 //
 // The manager is expected to be called synchronously by the sync
 // controller which should ensure that the version object for a given
@@ -288,20 +351,9 @@ func (m *Manager) writeVersion(obj runtimeclient.Object, qualifiedName utils.Qua
 	if err != nil {
 		return errors.Wrapf(err, "Failed to retrieve the resourceVersion from %s %q", adapterType, key)
 	}
-	refreshVersion := false
 	// TODO(marun) Centralize polling interval and duration
 	waitDuration := 30 * time.Second
 	err = wait.PollUntilContextTimeout(context.Background(), 100*time.Millisecond, waitDuration, true, func(ctx context.Context) (done bool, err error) {
-		if refreshVersion {
-			// Version was written to the API by another process after the last manager write.
-			resourceVersion, err = m.getResourceVersionFromAPI(qualifiedName)
-			if err != nil {
-				runtime.HandleError(errors.Wrapf(err, "Failed to refresh the resourceVersion for %s %q from the API", adapterType, key))
-				return false, nil
-			}
-			refreshVersion = false
-		}
-
 		if resourceVersion == "" {
 			// Version resource needs to be created
 
@@ -315,9 +367,8 @@ func (m *Manager) writeVersion(obj runtimeclient.Object, qualifiedName utils.Qua
 			klog.V(4).Infof("Creating %s %q", adapterType, qualifiedName)
 			err = m.client.Create(context.TODO(), createdObj)
 			if apierrors.IsAlreadyExists(err) {
-				klog.V(4).Infof("%s %q was created by another process. Will refresh the resourceVersion and attempt to update.", adapterType, qualifiedName)
-				refreshVersion = true
-				return false, nil
+				klog.V(4).Infof("%s %q was created by another process. Will refresh it from the API.", adapterType, qualifiedName)
+				return m.refreshVersion(qualifiedName)
 			}
 			// Forbidden is likely to be a permanent failure and
 			// likely the result of the containing namespace being
@@ -350,9 +401,8 @@ func (m *Manager) writeVersion(obj runtimeclient.Object, qualifiedName utils.Qua
 		klog.V(4).Infof("Updating the status of %s %q", adapterType, qualifiedName)
 		err = m.client.UpdateStatus(context.TODO(), updatedObj)
 		if apierrors.IsConflict(err) {
-			klog.V(4).Infof("%s %q was updated by another process. Will refresh the resourceVersion and retry the update.", adapterType, qualifiedName)
-			refreshVersion = true
-			return false, nil
+			klog.V(4).Infof("%s %q was updated by another process. Will refresh it from the API.", adapterType, qualifiedName)
+			return m.refreshVersion(qualifiedName)
 		}
 		if apierrors.IsNotFound(err) {
 			klog.V(4).Infof("%s %q was deleted by another process. Will clear the resourceVersion and retry the update.", adapterType, qualifiedName)
@@ -393,14 +443,21 @@ func (m *Manager) writeVersion(obj runtimeclient.Object, qualifiedName utils.Qua
 	return nil
 }
 
-func (m *Manager) getResourceVersionFromAPI(qualifiedName utils.QualifiedName) (string, error) {
-	klog.V(4).Infof("Retrieving resourceVersion for %s %q from the API", m.federatedKind, qualifiedName)
+// refreshVersion replaces the cached version with the named version
+// retrieved from the API.  It returns errStaleVersion once the version
+// is refreshed so that the versions to record are merged again.
+func (m *Manager) refreshVersion(qualifiedName utils.QualifiedName) (bool, error) {
+	klog.V(4).Infof("Retrieving %s %q from the API", m.adapter.TypeName(), qualifiedName)
 	obj := m.adapter.NewObject()
 	err := m.client.Get(context.TODO(), obj, qualifiedName.Namespace, qualifiedName.Name)
 	if err != nil {
-		return "", err
+		runtime.HandleError(errors.Wrapf(err, "Failed to refresh %s %q from the API", m.adapter.TypeName(), qualifiedName))
+		return false, nil
 	}
-	return getResourceVersion(obj)
+	m.Lock()
+	m.versions[qualifiedName.String()] = obj
+	m.Unlock()
+	return false, errStaleVersion
 }
 
 func getResourceVersion(obj runtimeclient.Object) (string, error) {
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fedv1a1 "sigs.k8s.io/kubefed/pkg/apis/core/v1alpha1"
+	"sigs.k8s.io/kubefed/pkg/client/generic/scheme"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+// testClient adapts a controller-runtime client to generic.Client.
+type testClient struct {
+	client runtimeclient.Client
+}
+
+func (c *testClient) Create(ctx context.Context, obj runtimeclient.Object) error {
+	return c.client.Create(ctx, obj)
+}
+
+func (c *testClient) Get(ctx context.Context, obj runtimeclient.Object, namespace, name string) error {
+	return c.client.Get(ctx, runtimeclient.ObjectKey{Namespace: namespace, Name: name}, obj)
+}
+
+func (c *testClient) Update(ctx context.Context, obj runtimeclient.Object) error {
+	return c.client.Update(ctx, obj)
+}
+
+func (c *testClient) Delete(ctx context.Context, obj runtimeclient.Object, _, _ string, opts ...runtimeclient.DeleteOption) error {
+	return c.client.Delete(ctx, obj, opts...)
+}
+
+func (c *testClient) List(ctx context.Context, obj runtimeclient.ObjectList, namespace string, opts ...runtimeclient.ListOption) error {
+	return c.client.List(ctx, obj, append(opts, runtimeclient.InNamespace(namespace))...)
+}
+
+func (c *testClient) UpdateStatus(ctx context.Context, obj runtimeclient.Object) error {
+	return c.client.Status().Update(ctx, obj)
+}
+
+func (c *testClient) Patch(ctx context.Context, obj runtimeclient.Object, patch runtimeclient.Patch, opts ...runtimeclient.PatchOption) error {
+	return c.client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *testClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...runtimeclient.ApplyOption) error {
+	return c.client.Apply(ctx, obj, opts...)
+}
+
+type testResource struct {
+	obj *unstructured.Unstructured
+}
+
+func (r *testResource) FederatedName() utils.QualifiedName {
+	return utils.NewQualifiedName(r.obj)
+}
+
+func (r *testResource) Object() *unstructured.Unstructured {
+	return r.obj
+}
+
+func (r *testResource) TemplateVersion() (string, error) {
+	return "template", nil
+}
+
+func (r *testResource) OverrideVersion() (string, error) {
+	return "override", nil
+}
+
+func TestUpdateMergesVersionsWrittenByAnotherProcess(t *testing.T) {
+	client := &testClient{fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithStatusSubresource(&fedv1a1.PropagatedVersion{}).
+		Build()}
+	newManager := func() *Manager {
+		m := NewVersionManager(context.TODO(), false, client, true, "FederatedConfigMap", "ConfigMap", "ns")
+		m.Sync(nil)
+		return m
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("types.kubefed.io/v1beta1")
+	obj.SetKind("FederatedConfigMap")
+	obj.SetNamespace("ns")
+	obj.SetName("foo")
+	resource := &testResource{obj: obj}
+	clusters := []string{"cluster1", "cluster2"}
+
+	// Both replicas cache the versions before either writes them.
+	replica1, replica2 := newManager(), newManager()
+
+	if err := replica1.Update(resource, clusters, map[string]string{"cluster1": "1", "cluster2": "1"}); err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if err := replica2.Update(resource, clusters, map[string]string{"cluster1": "2"}); err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	expected := map[string]string{"cluster1": "2", "cluster2": "1"}
+	if versions, _ := replica2.Get(resource); !reflect.DeepEqual(versions, expected) {
+		t.Fatalf("Expected versions %v after a concurrent create, got %v", expected, versions)
+	}
+
+	if err := replica1.Update(resource, clusters, map[string]string{"cluster2": "3"}); err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	expected = map[string]string{"cluster1": "2", "cluster2": "3"}
+	if versions, _ := replica1.Get(resource); !reflect.DeepEqual(versions, expected) {
+		t.Fatalf("Expected versions %v after a concurrent update, got %v", expected, versions)
+	}
+
+	// A replica acquiring the resource retrieves the versions again.
+	if err := replica2.Refresh(); err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if versions, _ := replica2.Get(resource); !reflect.DeepEqual(versions, expected) {
+		t.Fatalf("Expected versions %v after a refresh, got %v", expected, versions)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+)
+
+// CacheTransform strips the fields of resources in member clusters
+// that are not needed by the controllers before the resources are
+// cached.  The metadata (without managed fields) and status of a
+// resource are kept, so that its version can be determined and its
+// status collected, along with the fields retained from member clusters
+// when the resource is updated and the fields used for scheduling.
+type CacheTransform struct {
+	retainFields []string
+	// paths of the fields kept in addition to the metadata and status.
+	paths [][]string
+}
+
+// CacheTransformForType returns the transform of the resources of the
+// given type, or nil if resources of the type are cached in full.
+func CacheTransformForType(typeConfig typeconfig.Interface) *CacheTransform {
+	if !typeConfig.GetMinimalCacheEnabled() {
+		return nil
+	}
+	return NewCacheTransform(typeConfig.GetTargetType().Kind, typeConfig.GetRetainFields())
+}
+
+// NewCacheTransform returns a transform that keeps the fields of the
+// given kind that are retained from member clusters, including the
+// fields at the given JSON pointers.
+func NewCacheTransform(targetKind string, retainFieldPaths []string) *CacheTransform {
+	t := &CacheTransform{
+		retainFields: retainFieldPaths,
+		paths: [][]string{
+			{SpecField, ReplicasField},
+			{SpecField, "selector"},
+		},
+	}
+	switch targetKind {
+	case ServiceKind:
+		t.paths = append(t.paths,
+			[]string{SpecField, HealthCheckNodePortField},
+			[]string{SpecField, ClusterIPField},
+			[]string{SpecField, ClusterIPsField},
+			[]string{SpecField, PortsField},
+		)
+	case ServiceAccountKind:
+		t.paths = append(t.paths, []string{SecretsField})
+	}
+	for _, path := range retainFieldPaths {
+		fields := strings.Split(strings.TrimPrefix(path, "/"), "/")
+		for i, field := range fields {
+			fields[i] = strings.ReplaceAll(strings.ReplaceAll(field, "~1", "/"), "~0", "~")
+		}
+		t.paths = append(t.paths, fields)
+	}
+	return t
+}
+
+// Transform returns a copy of the given resource with only the fields
+// kept by the transform.  It is suitable for use as the transform of a
+// shared informer.
+func (t *CacheTransform) Transform(obj interface{}) (interface{}, error) {
+	clusterObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return obj, nil
+	}
+
+	result := &unstructured.Unstructured{Object: make(map[string]interface{})}
+	for _, key := range []string{"apiVersion", "kind", MetadataField, StatusField} {
+		if value, ok := clusterObj.Object[key]; ok {
+			result.Object[key] = value
+		}
+	}
+	if metadata, ok := result.Object[MetadataField].(map[string]interface{}); ok {
+		if _, ok := metadata["managedFields"]; ok {
+			metadata = shallowCopyMap(metadata)
+			delete(metadata, "managedFields")
+			result.Object[MetadataField] = metadata
+		}
+	}
+	for _, path := range t.paths {
+		switch path[0] {
+		case "apiVersion", "kind", MetadataField, StatusField:
+			// Already kept in full.
+			continue
+		}
+		value, ok, err := unstructured.NestedFieldNoCopy(clusterObj.Object, path...)
+		if err != nil || !ok {
+			continue
+		}
+		if err := unstructured.SetNestedField(result.Object, value, path...); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// key identifies the fields kept by the transform so that informers
+// are only shared by controllers caching the same fields.
+func (t *CacheTransform) key() string {
+	if t == nil {
+		return ""
+	}
+	return "minimal:" + strings.Join(t.retainFields, ",")
+}
+
+func shallowCopyMap(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
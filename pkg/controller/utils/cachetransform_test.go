@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCacheTransform(t *testing.T) {
+	testCases := map[string]struct {
+		kind         string
+		retainFields []string
+		obj          map[string]interface{}
+		expected     map[string]interface{}
+	}{
+		"data and managed fields are stripped": {
+			kind: "ConfigMap",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":            "foo",
+					"resourceVersion": "5",
+					"managedFields":   []interface{}{map[string]interface{}{"manager": "kubefed"}},
+				},
+				"data": map[string]interface{}{"key": "value"},
+			},
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":            "foo",
+					"resourceVersion": "5",
+				},
+			},
+		},
+		"replicas, selector and status are kept": {
+			kind: "Deployment",
+			obj: map[string]interface{}{
+				"kind":     "Deployment",
+				"metadata": map[string]interface{}{"name": "foo", "generation": int64(2)},
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "foo"}},
+					"template": map[string]interface{}{"spec": map[string]interface{}{}},
+				},
+				"status": map[string]interface{}{"availableReplicas": int64(3)},
+			},
+			expected: map[string]interface{}{
+				"kind":     "Deployment",
+				"metadata": map[string]interface{}{"name": "foo", "generation": int64(2)},
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "foo"}},
+				},
+				"status": map[string]interface{}{"availableReplicas": int64(3)},
+			},
+		},
+		"retained service fields are kept": {
+			kind: ServiceKind,
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo"},
+				"spec": map[string]interface{}{
+					"clusterIP": "10.0.0.1",
+					"ports":     []interface{}{map[string]interface{}{"port": int64(80), "nodePort": int64(30080)}},
+					"type":      "NodePort",
+				},
+			},
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo"},
+				"spec": map[string]interface{}{
+					"clusterIP": "10.0.0.1",
+					"ports":     []interface{}{map[string]interface{}{"port": int64(80), "nodePort": int64(30080)}},
+				},
+			},
+		},
+		"configured retained fields are kept": {
+			kind:         "Secret",
+			retainFields: []string{"/data/generated~1key"},
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo"},
+				"data":     map[string]interface{}{"generated/key": "a2V5", "other": "b3RoZXI="},
+			},
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo"},
+				"data":     map[string]interface{}{"generated/key": "a2V5"},
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: testCase.obj}
+			original := obj.DeepCopy()
+			result, err := NewCacheTransform(testCase.kind, testCase.retainFields).Transform(obj)
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			resultObj := result.(*unstructured.Unstructured)
+			if !reflect.DeepEqual(resultObj.Object, testCase.expected) {
+				t.Fatalf("Expected %v, got %v", testCase.expected, resultObj.Object)
+			}
+			if !reflect.DeepEqual(obj, original) {
+				t.Fatalf("Expected the transformed object not to be modified")
+			}
+		})
+	}
+}
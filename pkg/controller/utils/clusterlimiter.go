@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/metrics"
+)
+
+// ClusterLimiters limits the rate and concurrency of the operations
+// propagated to each member cluster.  A single instance is shared by
+// the sync controllers of all federated types so that the limits of
+// a cluster apply to the sum of their operations.
+type ClusterLimiters struct {
+	sync.RWMutex
+
+	// defaults are the limits of clusters that do not configure
+	// their own.
+	defaults fedv1b1.PropagationRateLimit
+
+	limiters map[string]*clusterLimiter
+}
+
+type clusterLimiter struct {
+	config fedv1b1.PropagationRateLimit
+
+	// rate is nil if the rate of operations is not limited.
+	rate *rate.Limiter
+	// inflight is nil if the number of operations in flight is not
+	// limited.
+	inflight chan struct{}
+}
+
+// NewClusterLimiters returns limiters that apply the given default
+// limits to clusters that do not configure their own.
+func NewClusterLimiters(defaults *fedv1b1.PropagationRateLimit) *ClusterLimiters {
+	l := &ClusterLimiters{
+		limiters: make(map[string]*clusterLimiter),
+	}
+	if defaults != nil {
+		l.defaults = *defaults.DeepCopy()
+	}
+	return l
+}
+
+// UpdateCluster configures the limiter of the given cluster.  The
+// limiter is only replaced if its effective limits have changed so
+// that it may be called on every update of the cluster.
+func (l *ClusterLimiters) UpdateCluster(cluster *fedv1b1.KubeFedCluster) {
+	if l == nil {
+		return
+	}
+	config := effectiveRateLimit(&l.defaults, cluster.Spec.PropagationRateLimit)
+
+	l.Lock()
+	defer l.Unlock()
+	if limiter, ok := l.limiters[cluster.Name]; ok && equality.Semantic.DeepEqual(limiter.config, config) {
+		return
+	}
+	l.limiters[cluster.Name] = newClusterLimiter(config)
+}
+
+// RemoveCluster removes the limiter of the named cluster.
+func (l *ClusterLimiters) RemoveCluster(clusterName string) {
+	if l == nil {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	delete(l.limiters, clusterName)
+}
+
+// Acquire blocks until an operation may be propagated to the named
+// cluster or the given context is done.  The returned function must be
+// called once the operation completes.  Operations against clusters
+// without a limiter are never delayed.
+func (l *ClusterLimiters) Acquire(ctx context.Context, clusterName string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	l.RLock()
+	limiter, ok := l.limiters[clusterName]
+	l.RUnlock()
+	if !ok {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	throttled, err := limiter.wait(ctx)
+	if throttled {
+		metrics.ClusterPropagationThrottledFromStart(clusterName, start)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Propagation to cluster %q was throttled for %v", clusterName, time.Since(start).Round(time.Millisecond))
+	}
+
+	metrics.ClusterPropagationInflightInc(clusterName)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			metrics.ClusterPropagationInflightDec(clusterName)
+			limiter.release()
+		})
+	}, nil
+}
+
+func newClusterLimiter(config fedv1b1.PropagationRateLimit) *clusterLimiter {
+	limiter := &clusterLimiter{config: config}
+	if config.QPS != nil {
+		burst := *config.QPS
+		if config.Burst != nil {
+			burst = *config.Burst
+		}
+		limiter.rate = rate.NewLimiter(rate.Limit(*config.QPS), int(burst))
+	}
+	if config.MaxInflight != nil {
+		limiter.inflight = make(chan struct{}, *config.MaxInflight)
+	}
+	return limiter
+}
+
+// wait blocks until a slot for an operation in flight is available and
+// the rate limit allows the operation.  throttled indicates whether the
+// operation had to wait.
+func (c *clusterLimiter) wait(ctx context.Context) (throttled bool, err error) {
+	if c.inflight != nil {
+		select {
+		case c.inflight <- struct{}{}:
+		default:
+			throttled = true
+			select {
+			case c.inflight <- struct{}{}:
+			case <-ctx.Done():
+				return throttled, ctx.Err()
+			}
+		}
+	}
+
+	if c.rate == nil {
+		return throttled, nil
+	}
+	reservation := c.rate.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return throttled, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		c.release()
+		return true, ctx.Err()
+	}
+}
+
+func (c *clusterLimiter) release() {
+	if c.inflight != nil {
+		<-c.inflight
+	}
+}
+
+// effectiveRateLimit returns the limits of a cluster, with the limits it
+// does not configure defaulted.
+func effectiveRateLimit(defaults, cluster *fedv1b1.PropagationRateLimit) fedv1b1.PropagationRateLimit {
+	config := *defaults.DeepCopy()
+	if cluster == nil {
+		return config
+	}
+	if cluster.QPS != nil {
+		config.QPS = cluster.QPS
+		// A default burst would not be consistent with the qps of
+		// the cluster.
+		config.Burst = nil
+	}
+	if cluster.Burst != nil {
+		config.Burst = cluster.Burst
+	}
+	if cluster.MaxInflight != nil {
+		config.MaxInflight = cluster.MaxInflight
+	}
+	return *config.DeepCopy()
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestEffectiveRateLimit(t *testing.T) {
+	one, two, three := int64(1), int64(2), int64(3)
+	defaults := &fedv1b1.PropagationRateLimit{QPS: &one, Burst: &two, MaxInflight: &three}
+
+	testCases := map[string]struct {
+		cluster  *fedv1b1.PropagationRateLimit
+		expected fedv1b1.PropagationRateLimit
+	}{
+		"cluster without limits uses the defaults": {
+			expected: *defaults,
+		},
+		"cluster overrides max inflight": {
+			cluster:  &fedv1b1.PropagationRateLimit{MaxInflight: &one},
+			expected: fedv1b1.PropagationRateLimit{QPS: &one, Burst: &two, MaxInflight: &one},
+		},
+		"cluster qps clears the default burst": {
+			cluster:  &fedv1b1.PropagationRateLimit{QPS: &three},
+			expected: fedv1b1.PropagationRateLimit{QPS: &three, MaxInflight: &three},
+		},
+		"cluster overrides qps and burst": {
+			cluster:  &fedv1b1.PropagationRateLimit{QPS: &three, Burst: &three},
+			expected: fedv1b1.PropagationRateLimit{QPS: &three, Burst: &three, MaxInflight: &three},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			result := effectiveRateLimit(defaults, testCase.cluster)
+			if !reflect.DeepEqual(result, testCase.expected) {
+				t.Fatalf("Expected limits %v, got %v", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestClusterLimitersMaxInflight(t *testing.T) {
+	maxInflight := int64(1)
+	limiters := NewClusterLimiters(&fedv1b1.PropagationRateLimit{MaxInflight: &maxInflight})
+	cluster := &fedv1b1.KubeFedCluster{}
+	cluster.Name = "cluster1"
+	limiters.UpdateCluster(cluster)
+
+	release, err := limiters.Acquire(context.Background(), cluster.Name)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = limiters.Acquire(ctx, cluster.Name)
+	if err == nil {
+		t.Fatalf("Expected an operation to be throttled while the maximum number of operations are in flight")
+	}
+
+	// Updating the cluster with the same limits must not reset the
+	// operations in flight.
+	limiters.UpdateCluster(cluster)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = limiters.Acquire(ctx, cluster.Name)
+	if err == nil {
+		t.Fatalf("Expected an operation to be throttled after an update that does not change limits")
+	}
+
+	release()
+	release, err = limiters.Acquire(context.Background(), cluster.Name)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	release()
+
+	_, err = limiters.Acquire(context.Background(), "unknown")
+	if err != nil {
+		t.Fatalf("Expected operations against a cluster without a limiter not to be throttled: %v", err)
+	}
+}
+
+func TestClusterLimitersQPS(t *testing.T) {
+	qps, burst := int64(1), int64(1)
+	limiters := NewClusterLimiters(nil)
+	cluster := &fedv1b1.KubeFedCluster{}
+	cluster.Name = "cluster1"
+	cluster.Spec.PropagationRateLimit = &fedv1b1.PropagationRateLimit{QPS: &qps, Burst: &burst}
+	limiters.UpdateCluster(cluster)
+
+	release, err := limiters.Acquire(context.Background(), cluster.Name)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = limiters.Acquire(ctx, cluster.Name)
+	if err == nil {
+		t.Fatalf("Expected an operation exceeding the burst to be throttled")
+	}
+
+	limiters.RemoveCluster(cluster.Name)
+	release, err = limiters.Acquire(context.Background(), cluster.Name)
+	if err != nil {
+		t.Fatalf("Expected operations against a removed cluster not to be throttled: %v", err)
+	}
+	release()
+}
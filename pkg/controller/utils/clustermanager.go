@@ -0,0 +1,330 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+)
+
+// ClusterManager pools the connections to member clusters and the
+// informers watching them.  A single instance is shared by the
+// controllers of all federated types so that a member cluster is
+// watched once per target type regardless of the number of
+// controllers interested in it.
+type ClusterManager struct {
+	sync.Mutex
+
+	kubeConfig *restclient.Config
+
+	// Namespace from which to source KubeFedCluster resources
+	fedNamespace string
+
+	// Retrieves configuration to access a cluster.
+	configFactory func(*fedv1b1.KubeFedCluster) (*restclient.Config, error)
+
+	// Informer on federated clusters, created on first use.
+	clusterInformer cache.SharedIndexInformer
+	// Whether the informer on federated clusters has been started.
+	clusterInformerStarted bool
+
+	clusters map[string]*managedCluster
+
+	stopChan chan struct{}
+}
+
+// managedCluster holds the connection to a member cluster and the
+// informers watching it.  It is replaced when the spec of the cluster
+// changes, and the informers of the replaced connection are only kept
+// running until released by the controllers that use them.
+type managedCluster struct {
+	uid  types.UID
+	spec fedv1b1.KubeFedClusterSpec
+
+	config *restclient.Config
+	// client is created on first use.
+	client generic.Client
+
+	informers map[informerKey]*sharedInformer
+}
+
+type informerKey struct {
+	resource  schema.GroupVersionResource
+	namespace string
+	transform string
+}
+
+type sharedInformer struct {
+	informer cache.SharedIndexInformer
+	stopChan chan struct{}
+	// refs is the number of controllers using the informer.
+	refs int
+}
+
+// NewClusterManager returns a manager of the connections to the
+// clusters registered in the given KubeFed namespace.
+func NewClusterManager(kubeConfig *restclient.Config, fedNamespace string) (*ClusterManager, error) {
+	hostConfig := restclient.CopyConfig(kubeConfig)
+	restclient.AddUserAgent(hostConfig, "cluster-manager")
+	client, err := generic.New(hostConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newClusterManager(kubeConfig, fedNamespace, client), nil
+}
+
+func newClusterManager(kubeConfig *restclient.Config, fedNamespace string, client generic.Client) *ClusterManager {
+	return &ClusterManager{
+		kubeConfig:   kubeConfig,
+		fedNamespace: fedNamespace,
+		configFactory: func(cluster *fedv1b1.KubeFedCluster) (*restclient.Config, error) {
+			clusterConfig, err := BuildClusterConfig(cluster, client, fedNamespace)
+			if err != nil {
+				return nil, err
+			}
+			if clusterConfig == nil {
+				return nil, errors.Errorf("Unable to load configuration for cluster %q", cluster.Name)
+			}
+			restclient.AddUserAgent(clusterConfig, userAgentName)
+			return clusterConfig, nil
+		},
+		clusters: make(map[string]*managedCluster),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// ClusterStore returns the store of the informer on federated
+// clusters.  The store is only populated once a handler has been added
+// with AddClusterEventHandler.
+func (m *ClusterManager) ClusterStore() (cache.Store, error) {
+	m.Lock()
+	defer m.Unlock()
+	informer, err := m.getClusterInformerLocked()
+	if err != nil {
+		return nil, err
+	}
+	return informer.GetStore(), nil
+}
+
+// AddClusterEventHandler adds a handler to the informer on federated
+// clusters, starting the informer if necessary.
+func (m *ClusterManager) AddClusterEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	m.Lock()
+	defer m.Unlock()
+	informer, err := m.getClusterInformerLocked()
+	if err != nil {
+		return nil, err
+	}
+	registration, err := informer.AddEventHandler(handler)
+	if err != nil {
+		return nil, err
+	}
+	if !m.clusterInformerStarted {
+		m.clusterInformerStarted = true
+		go informer.Run(m.stopChan)
+	}
+	return registration, nil
+}
+
+// RemoveClusterEventHandler removes a handler added with
+// AddClusterEventHandler.
+func (m *ClusterManager) RemoveClusterEventHandler(registration cache.ResourceEventHandlerRegistration) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.clusterInformer == nil {
+		return nil
+	}
+	return m.clusterInformer.RemoveEventHandler(registration)
+}
+
+func (m *ClusterManager) getClusterInformerLocked() (cache.SharedIndexInformer, error) {
+	if m.clusterInformer != nil {
+		return m.clusterInformer, nil
+	}
+	informer, err := NewGenericSharedInformer(m.kubeConfig, m.fedNamespace, &fedv1b1.KubeFedCluster{}, clusterSyncPeriod)
+	if err != nil {
+		return nil, err
+	}
+	// Connections to a cluster that is deleted or no longer ready
+	// are discarded so that the configuration of the cluster is
+	// retrieved again when it becomes ready.
+	_, err = informer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(old interface{}) {
+			if tombstone, ok := old.(cache.DeletedFinalStateUnknown); ok {
+				old = tombstone.Obj
+			}
+			if cluster, ok := old.(*fedv1b1.KubeFedCluster); ok {
+				m.forgetCluster(cluster.Name)
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if cluster, ok := cur.(*fedv1b1.KubeFedCluster); ok && !IsClusterReady(&cluster.Status) {
+				m.forgetCluster(cluster.Name)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.clusterInformer = informer
+	return informer, nil
+}
+
+// Client returns a client for the given cluster.
+func (m *ClusterManager) Client(cluster *fedv1b1.KubeFedCluster) (generic.Client, error) {
+	m.Lock()
+	defer m.Unlock()
+	managed, err := m.getClusterLocked(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if managed.client == nil {
+		client, err := generic.New(managed.config)
+		if err != nil {
+			return nil, err
+		}
+		managed.client = client
+	}
+	return managed.client, nil
+}
+
+// Informer adds the given handler to an informer on the KubeFed
+// managed resources of the given type and namespace in the given
+// cluster.  Resources are cached in full unless a transform is given.
+// The informer is shared with the other controllers watching the same
+// resources with the same transform.  The returned function must be
+// called once the informer is no longer needed, and stops the informer
+// when no other controller uses it.
+func (m *ClusterManager) Informer(cluster *fedv1b1.KubeFedCluster, apiResource *metav1.APIResource, namespace string,
+	transform *CacheTransform, handler cache.ResourceEventHandler) (cache.Store, cache.ResourceEventHandlerRegistration, func(), error) {
+	m.Lock()
+	defer m.Unlock()
+	managed, err := m.getClusterLocked(cluster)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	key := informerKey{
+		resource:  schema.GroupVersionResource{Group: apiResource.Group, Version: apiResource.Version, Resource: apiResource.Name},
+		namespace: namespace,
+		transform: transform.key(),
+	}
+	shared, ok := managed.informers[key]
+	if !ok {
+		resourceClient, err := NewResourceClient(managed.config, apiResource)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		shared = &sharedInformer{
+			informer: NewManagedResourceSharedInformer(resourceClient, namespace, apiResource),
+			stopChan: make(chan struct{}),
+		}
+		if transform != nil {
+			if err := shared.informer.SetTransform(transform.Transform); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		managed.informers[key] = shared
+		go shared.informer.Run(shared.stopChan)
+		klog.V(4).Infof("Started informer for %s in namespace %q of cluster %q", key.resource, namespace, cluster.Name)
+	}
+	registration, err := shared.informer.AddEventHandler(handler)
+	if err != nil {
+		if shared.refs == 0 {
+			close(shared.stopChan)
+			delete(managed.informers, key)
+		}
+		return nil, nil, nil, err
+	}
+	shared.refs++
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			m.Lock()
+			defer m.Unlock()
+			if err := shared.informer.RemoveEventHandler(registration); err != nil {
+				klog.Errorf("Failed to remove event handler from informer for %s of cluster %q: %v", key.resource, cluster.Name, err)
+			}
+			shared.refs--
+			if shared.refs > 0 {
+				return
+			}
+			close(shared.stopChan)
+			if managed.informers[key] == shared {
+				delete(managed.informers, key)
+			}
+			klog.V(4).Infof("Stopped informer for %s in namespace %q of cluster %q", key.resource, namespace, cluster.Name)
+		})
+	}
+	return shared.informer.GetStore(), registration, release, nil
+}
+
+// getClusterLocked returns the connection to the given cluster,
+// replacing an existing connection established for a different spec.
+func (m *ClusterManager) getClusterLocked(cluster *fedv1b1.KubeFedCluster) (*managedCluster, error) {
+	if managed, ok := m.clusters[cluster.Name]; ok {
+		if managed.uid == cluster.UID && equality.Semantic.DeepEqual(managed.spec, cluster.Spec) {
+			return managed, nil
+		}
+		klog.V(4).Infof("Replacing connection to cluster %q after a change to its spec", cluster.Name)
+	}
+	config, err := m.configFactory(cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "Client creation failed")
+	}
+	managed := &managedCluster{
+		uid:       cluster.UID,
+		spec:      *cluster.Spec.DeepCopy(),
+		config:    config,
+		informers: make(map[informerKey]*sharedInformer),
+	}
+	m.clusters[cluster.Name] = managed
+	return managed, nil
+}
+
+// forgetCluster discards the connection to the named cluster.  Its
+// informers keep running until released.
+func (m *ClusterManager) forgetCluster(clusterName string) {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.clusters, clusterName)
+}
+
+// Stop stops the informer on federated clusters.  The informers of
+// member clusters are stopped as they are released.
+func (m *ClusterManager) Stop() {
+	m.Lock()
+	defer m.Unlock()
+	select {
+	case <-m.stopChan:
+	default:
+		close(m.stopChan)
+	}
+}
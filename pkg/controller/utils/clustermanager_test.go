@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func newTestClusterManager() (*ClusterManager, *int) {
+	configs := 0
+	m := newClusterManager(&restclient.Config{}, "kube-federation-system", nil)
+	m.configFactory = func(cluster *fedv1b1.KubeFedCluster) (*restclient.Config, error) {
+		configs++
+		// The informers are never synced against an unreachable
+		// endpoint, which is sufficient to test their sharing.
+		return &restclient.Config{Host: "https://127.0.0.1:1"}, nil
+	}
+	return m, &configs
+}
+
+func TestClusterManagerSharesInformers(t *testing.T) {
+	m, _ := newTestClusterManager()
+	defer m.Stop()
+	cluster := &fedv1b1.KubeFedCluster{}
+	cluster.Name = "cluster1"
+	configMaps := &metav1.APIResource{Version: "v1", Kind: "ConfigMap", Name: "configmaps", Namespaced: true}
+	secrets := &metav1.APIResource{Version: "v1", Kind: "Secret", Name: "secrets", Namespaced: true}
+
+	store1, _, release1, err := m.Informer(cluster, configMaps, "", nil, &cache.ResourceEventHandlerFuncs{})
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	store2, _, release2, err := m.Informer(cluster, configMaps, "", nil, &cache.ResourceEventHandlerFuncs{})
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if store1 != store2 {
+		t.Fatalf("Expected informers on the same resources to share a store")
+	}
+	_, _, release3, err := m.Informer(cluster, secrets, "", nil, &cache.ResourceEventHandlerFuncs{})
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+
+	managed := m.clusters[cluster.Name]
+	if len(managed.informers) != 2 {
+		t.Fatalf("Expected 2 informers, got %d", len(managed.informers))
+	}
+
+	release1()
+	// Releasing more than once must not release the informer for
+	// another user.
+	release1()
+	if len(managed.informers) != 2 {
+		t.Fatalf("Expected an informer to be kept while in use")
+	}
+	release2()
+	release3()
+	if len(managed.informers) != 0 {
+		t.Fatalf("Expected informers to be stopped once released, got %d", len(managed.informers))
+	}
+}
+
+func TestClusterManagerReplacesConnectionOnSpecChange(t *testing.T) {
+	m, configs := newTestClusterManager()
+	defer m.Stop()
+	cluster := &fedv1b1.KubeFedCluster{}
+	cluster.Name = "cluster1"
+	cluster.Spec.APIEndpoint = "https://cluster1"
+
+	client1, err := m.Client(cluster)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	client2, err := m.Client(cluster)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if client1 != client2 || *configs != 1 {
+		t.Fatalf("Expected the client of a cluster to be reused")
+	}
+
+	updated := cluster.DeepCopy()
+	updated.Spec.APIEndpoint = "https://cluster1.example.com"
+	client3, err := m.Client(updated)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if client3 == client1 || *configs != 2 {
+		t.Fatalf("Expected a new client after a change to the cluster spec")
+	}
+
+	m.forgetCluster(cluster.Name)
+	_, err = m.Client(updated)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if *configs != 3 {
+		t.Fatalf("Expected the configuration of a forgotten cluster to be retrieved again")
+	}
+}
@@ -21,6 +21,10 @@ import (
 )
 
 const (
+	// SyncFieldManager is the field manager used by the sync
+	// controller to server-side apply objects in member clusters.
+	SyncFieldManager = "kubefed-sync-controller"
+
 	// NoResyncPeriod Providing 0 duration to an informer indicates that resync should be delayed as long as possible
 	NoResyncPeriod = 0 * time.Second
 
@@ -58,9 +62,22 @@ const (
 	TemplateField = "template"
 
 	// Placement fields
-	PlacementField       = "placement"
-	ClusterSelectorField = "clusterSelector"
-	MatchLabelsField     = "matchLabels"
+	PlacementField         = "placement"
+	ClusterSelectorField   = "clusterSelector"
+	MatchLabelsField       = "matchLabels"
+	ExcludeClustersField   = "excludeClusters"
+	OperatorField          = "operator"
+	RegionsField           = "regions"
+	ZonesField             = "zones"
+	SpreadConstraintsField = "spreadConstraints"
+	TolerationsField       = "tolerations"
+	FailoverField          = "failover"
+	PlacementRefField      = "placementRef"
+
+	// Drift policy fields
+	DriftPolicyField  = "driftPolicy"
+	DriftPolicyRevert = "Revert"
+	DriftPolicyReport = "Report"
 
 	// Override fields
 	OverridesField        = "overrides"
@@ -23,6 +23,7 @@ import (
 	restclient "k8s.io/client-go/rest"
 
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/utils/sharding"
 )
 
 // LeaderElectionConfiguration defines the configuration of leader election
@@ -62,6 +63,11 @@ type ClusterHealthCheckConfig struct {
 	FailureThreshold int64
 	SuccessThreshold int64
 	Timeout          time.Duration
+	// Heartbeat enables watching the Leases of the nodes and critical
+	// components of member clusters.
+	Heartbeat            bool
+	MinReadyNodesPercent int64
+	CriticalComponents   []fedv1b1.ClusterComponent
 }
 
 // ControllerConfig defines the configuration common to KubeFed
@@ -76,7 +82,19 @@ type ControllerConfig struct {
 	MaxConcurrentSyncReconciles   int64
 	MaxConcurrentStatusReconciles int64
 	SkipAdoptingResources         bool
+	InheritNamespacePlacement     bool
 	RawResourceStatusCollection   bool
+	// ClusterLimiters limits the operations propagated to member
+	// clusters by the sync controllers of all federated types.
+	ClusterLimiters *ClusterLimiters
+	// ClusterManager shares the connections to member clusters and the
+	// informers watching them between the controllers of all federated
+	// types.
+	ClusterManager *ClusterManager
+	// Shards determines the federated resources reconciled by this
+	// replica of the controller manager.  Nil when reconciliation is
+	// not sharded.
+	Shards *sharding.Manager
 }
 
 func (c *ControllerConfig) LimitedScope() bool {
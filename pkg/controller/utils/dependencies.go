@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// InferDependenciesAnnotation disables the inference of
+	// dependencies on the federated ConfigMaps and Secrets referenced
+	// by the template of a federated resource when set to "false".
+	InferDependenciesAnnotation = "kubefed.io/infer-dependencies"
+
+	// DefaultDependencyAPIVersion is the API version of dependencies
+	// that do not specify one.
+	DefaultDependencyAPIVersion = "types.kubefed.io/v1beta1"
+)
+
+// GenericDependency references a federated resource that must be
+// propagated to a cluster before a dependent federated resource is
+// created in that cluster.
+type GenericDependency struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// Namespace defaults to the namespace of the dependent resource.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Inferred indicates that the dependency was inferred from the
+	// template of the dependent resource rather than declared.
+	Inferred bool `json:"-"`
+}
+
+type GenericDependenciesSpec struct {
+	DependsOn []GenericDependency `json:"dependsOn,omitempty"`
+}
+
+type GenericDependencies struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GenericDependenciesSpec `json:"spec,omitempty"`
+}
+
+// GetDependencies returns the dependencies of the given federated
+// resource, both declared and inferred from its template, with
+// defaults applied.
+func GetDependencies(fedObject *unstructured.Unstructured) ([]GenericDependency, error) {
+	deps := &GenericDependencies{}
+	err := UnstructuredToInterface(fedObject, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependencies []GenericDependency
+	declared := map[GenericDependency]bool{}
+	for _, dependency := range deps.Spec.DependsOn {
+		if dependency.APIVersion == "" {
+			dependency.APIVersion = DefaultDependencyAPIVersion
+		}
+		if dependency.Namespace == "" {
+			dependency.Namespace = fedObject.GetNamespace()
+		}
+		declared[dependency] = true
+		dependencies = append(dependencies, dependency)
+	}
+
+	if fedObject.GetAnnotations()[InferDependenciesAnnotation] == "false" {
+		return dependencies, nil
+	}
+	for _, dependency := range InferDependencies(fedObject) {
+		key := dependency
+		key.Inferred = false
+		if !declared[key] {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies, nil
+}
+
+// InferDependencies returns dependencies on the federated ConfigMaps
+// and Secrets in the namespace of the given federated resource that
+// are referenced by its template through volumes, environment
+// variables or image pull secrets.  Optional references are ignored.
+func InferDependencies(fedObject *unstructured.Unstructured) []GenericDependency {
+	namespace := fedObject.GetNamespace()
+	if namespace == "" {
+		return nil
+	}
+	template, ok, _ := unstructured.NestedMap(fedObject.Object, SpecField, TemplateField)
+	if !ok {
+		return nil
+	}
+
+	refs := map[string]map[string]bool{
+		"ConfigMap": {},
+		"Secret":    {},
+	}
+	collectReferences(template, refs)
+
+	var dependencies []GenericDependency
+	for _, kind := range []string{"ConfigMap", "Secret"} {
+		names := make([]string, 0, len(refs[kind]))
+		for name := range refs[kind] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			dependencies = append(dependencies, GenericDependency{
+				APIVersion: DefaultDependencyAPIVersion,
+				Kind:       "Federated" + kind,
+				Name:       name,
+				Namespace:  namespace,
+				Inferred:   true,
+			})
+		}
+	}
+	return dependencies
+}
+
+// referenceFields maps the fields of a pod spec that reference a
+// ConfigMap or Secret to the kind referenced and the field holding the
+// name of the referenced object.
+var referenceFields = map[string]struct{ kind, nameField string }{
+	"configMap":       {"ConfigMap", "name"},
+	"configMapRef":    {"ConfigMap", "name"},
+	"configMapKeyRef": {"ConfigMap", "name"},
+	"secretRef":       {"Secret", "name"},
+	"secretKeyRef":    {"Secret", "name"},
+}
+
+func collectReferences(value interface{}, refs map[string]map[string]bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			collectReferences(item, refs)
+		}
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			if ref, ok := referenceFields[field]; ok {
+				addReference(fieldValue, ref.kind, ref.nameField, refs)
+			}
+			switch field {
+			case "secret":
+				// Secret volumes reference the secret by secretName
+				// and projected volume sources by name.
+				addReference(fieldValue, "Secret", "secretName", refs)
+				addReference(fieldValue, "Secret", "name", refs)
+			case "imagePullSecrets":
+				items, _ := fieldValue.([]interface{})
+				for _, item := range items {
+					addReference(item, "Secret", "name", refs)
+				}
+			}
+			collectReferences(fieldValue, refs)
+		}
+	}
+}
+
+func addReference(value interface{}, kind, nameField string, refs map[string]map[string]bool) {
+	ref, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if optional, _ := ref["optional"].(bool); optional {
+		return
+	}
+	if name, _ := ref[nameField].(string); name != "" {
+		refs[kind][name] = true
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetDependencies(t *testing.T) {
+	podSpec := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name": "app",
+				"envFrom": []interface{}{
+					map[string]interface{}{
+						"configMapRef": map[string]interface{}{"name": "env"},
+					},
+				},
+				"env": []interface{}{
+					map[string]interface{}{
+						"name": "PASSWORD",
+						"valueFrom": map[string]interface{}{
+							"secretKeyRef": map[string]interface{}{"name": "credentials", "key": "password"},
+						},
+					},
+					map[string]interface{}{
+						"name": "DEBUG",
+						"valueFrom": map[string]interface{}{
+							"configMapKeyRef": map[string]interface{}{"name": "debug", "key": "debug", "optional": true},
+						},
+					},
+				},
+			},
+		},
+		"volumes": []interface{}{
+			map[string]interface{}{
+				"name":      "config",
+				"configMap": map[string]interface{}{"name": "config"},
+			},
+			map[string]interface{}{
+				"name":   "tls",
+				"secret": map[string]interface{}{"secretName": "tls"},
+			},
+			map[string]interface{}{
+				"name": "projected",
+				"projected": map[string]interface{}{
+					"sources": []interface{}{
+						map[string]interface{}{
+							"secret": map[string]interface{}{"name": "token"},
+						},
+					},
+				},
+			},
+		},
+		"imagePullSecrets": []interface{}{
+			map[string]interface{}{"name": "registry"},
+		},
+	}
+	newFedObject := func(namespace string, annotations map[string]string, dependsOn []interface{}) *unstructured.Unstructured {
+		spec := map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": podSpec,
+					},
+				},
+			},
+		}
+		if dependsOn != nil {
+			spec["dependsOn"] = dependsOn
+		}
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		obj.SetNamespace(namespace)
+		obj.SetName("app")
+		obj.SetAnnotations(annotations)
+		return obj
+	}
+	inferred := func(kind, name string) GenericDependency {
+		return GenericDependency{APIVersion: DefaultDependencyAPIVersion, Kind: kind, Name: name, Namespace: "ns", Inferred: true}
+	}
+	declared := func(kind, name string) GenericDependency {
+		return GenericDependency{APIVersion: DefaultDependencyAPIVersion, Kind: kind, Name: name, Namespace: "ns"}
+	}
+
+	testCases := map[string]struct {
+		fedObject *unstructured.Unstructured
+		expected  []GenericDependency
+	}{
+		"references are inferred": {
+			fedObject: newFedObject("ns", nil, nil),
+			expected: []GenericDependency{
+				inferred("FederatedConfigMap", "config"),
+				inferred("FederatedConfigMap", "env"),
+				inferred("FederatedSecret", "credentials"),
+				inferred("FederatedSecret", "registry"),
+				inferred("FederatedSecret", "tls"),
+				inferred("FederatedSecret", "token"),
+			},
+		},
+		"declared dependencies are not duplicated": {
+			fedObject: newFedObject("ns", map[string]string{InferDependenciesAnnotation: "true"}, []interface{}{
+				map[string]interface{}{"kind": "FederatedConfigMap", "name": "config"},
+				map[string]interface{}{"apiVersion": "example.io/v1", "kind": "FederatedDatabase", "name": "db", "namespace": "data"},
+			}),
+			expected: []GenericDependency{
+				declared("FederatedConfigMap", "config"),
+				{APIVersion: "example.io/v1", Kind: "FederatedDatabase", Name: "db", Namespace: "data"},
+				inferred("FederatedConfigMap", "env"),
+				inferred("FederatedSecret", "credentials"),
+				inferred("FederatedSecret", "registry"),
+				inferred("FederatedSecret", "tls"),
+				inferred("FederatedSecret", "token"),
+			},
+		},
+		"inference disabled": {
+			fedObject: newFedObject("ns", map[string]string{InferDependenciesAnnotation: "false"}, []interface{}{
+				map[string]interface{}{"kind": "FederatedSecret", "name": "tls"},
+			}),
+			expected: []GenericDependency{
+				declared("FederatedSecret", "tls"),
+			},
+		},
+		"no inference for cluster-scoped resources": {
+			fedObject: newFedObject("", nil, nil),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			dependencies, err := GetDependencies(testCase.fedObject)
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			if !reflect.DeepEqual(dependencies, testCase.expected) {
+				t.Fatalf("Expected dependencies %v, got %v", testCase.expected, dependencies)
+			}
+		})
+	}
+}
@@ -27,7 +27,6 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
@@ -101,7 +100,7 @@ type RegisteredClustersView interface {
 // registered clusters.
 //
 // Whenever a new cluster is registered with KubeFed, an informer is
-// created for it using TargetInformerFactory. Informers are stopped
+// obtained for it from the ClusterManager. Informers are released
 // when a cluster is either put offline of deleted. It is assumed that
 // some controller keeps an eye on the cluster list and thus the
 // clusters in ETCD are up-to-date.
@@ -118,10 +117,6 @@ type FederatedInformer interface {
 	Stop()
 }
 
-// TargetInformerFactory A function that should be used to create an informer on the target object. Store should use
-// cache.DeletionHandlingMetaNamespaceKeyFunc as a keying function.
-type TargetInformerFactory func(*fedv1b1.KubeFedCluster, *restclient.Config) (cache.Store, cache.Controller, error)
-
 // ClusterLifecycleHandlerFuncs A structure with cluster lifecycle handler functions. Cluster is available (and ClusterAvailable is fired)
 // when it is created in federated etcd and ready. Cluster becomes unavailable (and ClusterUnavailable is fired)
 // when it is either deleted or becomes not ready. When cluster spec (IP)is modified both ClusterAvailable
@@ -135,38 +130,37 @@ type ClusterLifecycleHandlerFuncs struct {
 }
 
 // NewFederatedInformer Builds a FederatedInformer for the given configuration.
+// Connections to member clusters and informers are obtained from the
+// ClusterManager of the configuration, or from a manager private to
+// the returned informer if the configuration does not include one.
+// Resources in member clusters are cached in full unless a transform
+// is given.
 func NewFederatedInformer(
 	config *ControllerConfig,
 	client generic.Client,
 	apiResource *metav1.APIResource,
+	transform *CacheTransform,
 	triggerFunc func(runtimeclient.Object),
 	clusterLifecycle *ClusterLifecycleHandlerFuncs) (FederatedInformer, error) {
-	targetInformerFactory := func(cluster *fedv1b1.KubeFedCluster, clusterConfig *restclient.Config) (cache.Store, cache.Controller, error) {
-		resourceClient, err := NewResourceClient(clusterConfig, apiResource)
-		if err != nil {
-			return nil, nil, err
-		}
-		targetNamespace := NamespaceForCluster(cluster.Name, config.TargetNamespace)
-		store, controller := NewManagedResourceInformer(resourceClient, targetNamespace, apiResource, triggerFunc)
-		return store, controller, nil
+	manager := config.ClusterManager
+	ownsManager := manager == nil
+	if ownsManager {
+		manager = newClusterManager(config.KubeConfig, config.KubeFedNamespace, client)
 	}
 
 	federatedInformer := &federatedInformerImpl{
-		targetInformerFactory: targetInformerFactory,
-		configFactory: func(cluster *fedv1b1.KubeFedCluster) (*restclient.Config, error) {
-			clusterConfig, err := BuildClusterConfig(cluster, client, config.KubeFedNamespace)
+		manager:     manager,
+		ownsManager: ownsManager,
+		targetInformerFactory: func(cluster *fedv1b1.KubeFedCluster) (informer, error) {
+			targetNamespace := NamespaceForCluster(cluster.Name, config.TargetNamespace)
+			store, registration, release, err := manager.Informer(cluster, apiResource, targetNamespace, transform, NewTriggerOnAllChanges(triggerFunc))
 			if err != nil {
-				return nil, err
+				return informer{}, err
 			}
-			if clusterConfig == nil {
-				return nil, errors.Errorf("Unable to load configuration for cluster %q", cluster.Name)
-			}
-			restclient.AddUserAgent(clusterConfig, userAgentName)
-			return clusterConfig, nil
+			return informer{store: store, registration: registration, release: release}, nil
 		},
 		targetInformers: make(map[string]informer),
 		fedNamespace:    config.KubeFedNamespace,
-		clusterClients:  make(map[string]generic.Client),
 	}
 
 	getClusterData := func(name string) []interface{} {
@@ -179,74 +173,76 @@ func NewFederatedInformer(
 	}
 
 	var err error
-	federatedInformer.clusterInformer.store, federatedInformer.clusterInformer.controller, err = NewGenericInformerWithEventHandler(
-		config.KubeConfig,
-		config.KubeFedNamespace,
-		&fedv1b1.KubeFedCluster{},
-		clusterSyncPeriod,
-		&cache.ResourceEventHandlerFuncs{
-			DeleteFunc: func(old interface{}) {
-				oldCluster, ok := old.(*fedv1b1.KubeFedCluster)
-				if ok {
-					var data []interface{}
-					if clusterLifecycle.ClusterUnavailable != nil {
-						data = getClusterData(oldCluster.Name)
-					}
-					federatedInformer.deleteCluster(oldCluster)
-					if clusterLifecycle.ClusterUnavailable != nil {
-						clusterLifecycle.ClusterUnavailable(oldCluster, data)
-					}
+	federatedInformer.clusterStore, err = manager.ClusterStore()
+	if err != nil {
+		return nil, err
+	}
+	federatedInformer.clusterHandler = &cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(old interface{}) {
+			oldCluster, ok := old.(*fedv1b1.KubeFedCluster)
+			if ok {
+				var data []interface{}
+				if clusterLifecycle.ClusterUnavailable != nil {
+					data = getClusterData(oldCluster.Name)
 				}
-			},
-			AddFunc: func(cur interface{}) {
-				curCluster, ok := cur.(*fedv1b1.KubeFedCluster)
-				switch {
-				case !ok:
-					klog.Errorf("Cluster %v/%v not added; incorrect type", curCluster.Namespace, curCluster.Name)
-				case IsClusterReady(&curCluster.Status):
-					federatedInformer.addCluster(curCluster)
-					klog.Infof("Cluster %v/%v is ready", curCluster.Namespace, curCluster.Name)
-					if clusterLifecycle.ClusterAvailable != nil {
-						clusterLifecycle.ClusterAvailable(curCluster)
-					}
-				default:
-					klog.Infof("Cluster %v/%v not added; it is not ready.", curCluster.Namespace, curCluster.Name)
+				federatedInformer.deleteCluster(oldCluster)
+				config.ClusterLimiters.RemoveCluster(oldCluster.Name)
+				if clusterLifecycle.ClusterUnavailable != nil {
+					clusterLifecycle.ClusterUnavailable(oldCluster, data)
 				}
-			},
-			UpdateFunc: func(old, cur interface{}) {
-				oldCluster, ok := old.(*fedv1b1.KubeFedCluster)
-				if !ok {
-					klog.Errorf("Internal error: Cluster %v not updated. Old cluster not of correct type.", old)
-					return
+			}
+		},
+		AddFunc: func(cur interface{}) {
+			curCluster, ok := cur.(*fedv1b1.KubeFedCluster)
+			switch {
+			case !ok:
+				klog.Errorf("Cluster %v/%v not added; incorrect type", curCluster.Namespace, curCluster.Name)
+			case IsClusterReady(&curCluster.Status):
+				config.ClusterLimiters.UpdateCluster(curCluster)
+				federatedInformer.addCluster(curCluster)
+				klog.Infof("Cluster %v/%v is ready", curCluster.Namespace, curCluster.Name)
+				if clusterLifecycle.ClusterAvailable != nil {
+					clusterLifecycle.ClusterAvailable(curCluster)
 				}
-				curCluster, ok := cur.(*fedv1b1.KubeFedCluster)
-				if !ok {
-					klog.Errorf("Internal error: Cluster %v not updated. New cluster not of correct type.", cur)
-					return
+			default:
+				klog.Infof("Cluster %v/%v not added; it is not ready.", curCluster.Namespace, curCluster.Name)
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldCluster, ok := old.(*fedv1b1.KubeFedCluster)
+			if !ok {
+				klog.Errorf("Internal error: Cluster %v not updated. Old cluster not of correct type.", old)
+				return
+			}
+			curCluster, ok := cur.(*fedv1b1.KubeFedCluster)
+			if !ok {
+				klog.Errorf("Internal error: Cluster %v not updated. New cluster not of correct type.", cur)
+				return
+			}
+			if IsClusterReady(&oldCluster.Status) != IsClusterReady(&curCluster.Status) || !reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) || !reflect.DeepEqual(oldCluster.ObjectMeta.Labels, curCluster.ObjectMeta.Labels) || !reflect.DeepEqual(oldCluster.ObjectMeta.Annotations, curCluster.ObjectMeta.Annotations) ||
+				!reflect.DeepEqual(oldCluster.Status.Region, curCluster.Status.Region) || !reflect.DeepEqual(oldCluster.Status.Zones, curCluster.Status.Zones) {
+				var data []interface{}
+				if clusterLifecycle.ClusterUnavailable != nil {
+					data = getClusterData(oldCluster.Name)
+				}
+				federatedInformer.deleteCluster(oldCluster)
+				if clusterLifecycle.ClusterUnavailable != nil {
+					clusterLifecycle.ClusterUnavailable(oldCluster, data)
 				}
-				if IsClusterReady(&oldCluster.Status) != IsClusterReady(&curCluster.Status) || !reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) || !reflect.DeepEqual(oldCluster.ObjectMeta.Labels, curCluster.ObjectMeta.Labels) || !reflect.DeepEqual(oldCluster.ObjectMeta.Annotations, curCluster.ObjectMeta.Annotations) {
-					var data []interface{}
-					if clusterLifecycle.ClusterUnavailable != nil {
-						data = getClusterData(oldCluster.Name)
-					}
-					federatedInformer.deleteCluster(oldCluster)
-					if clusterLifecycle.ClusterUnavailable != nil {
-						clusterLifecycle.ClusterUnavailable(oldCluster, data)
-					}
 
-					if IsClusterReady(&curCluster.Status) {
-						federatedInformer.addCluster(curCluster)
-						if clusterLifecycle.ClusterAvailable != nil {
-							clusterLifecycle.ClusterAvailable(curCluster)
-						}
+				if IsClusterReady(&curCluster.Status) {
+					config.ClusterLimiters.UpdateCluster(curCluster)
+					federatedInformer.addCluster(curCluster)
+					if clusterLifecycle.ClusterAvailable != nil {
+						clusterLifecycle.ClusterAvailable(curCluster)
 					}
-				} else {
-					klog.V(7).Infof("Cluster %v not updated to %v as ready status and specs are identical", oldCluster, curCluster)
 				}
-			},
+			} else {
+				klog.V(7).Infof("Cluster %v not updated to %v as ready status and specs are identical", oldCluster, curCluster)
+			}
 		},
-	)
-	return federatedInformer, err
+	}
+	return federatedInformer, nil
 }
 
 func IsClusterReady(clusterStatus *fedv1b1.KubeFedClusterStatus) bool {
@@ -261,29 +257,36 @@ func IsClusterReady(clusterStatus *fedv1b1.KubeFedClusterStatus) bool {
 }
 
 type informer struct {
-	controller cache.Controller
-	store      cache.Store
-	stopChan   chan struct{}
+	store        cache.Store
+	registration cache.ResourceEventHandlerRegistration
+	// release must be called once the informer is no longer used.
+	release func()
 }
 
 type federatedInformerImpl struct {
 	sync.Mutex
 
-	// Informer on federated clusters.
-	clusterInformer informer
+	// Pools the connections to clusters and the informers watching
+	// them.
+	manager *ClusterManager
+	// Whether the manager is private to this informer and should be
+	// stopped with it.
+	ownsManager bool
+
+	// Store of the informer on federated clusters shared by all
+	// users of the manager.
+	clusterStore cache.Store
+	// Handler of changes to federated clusters and its registration,
+	// added on start.
+	clusterHandler      cache.ResourceEventHandler
+	clusterRegistration cache.ResourceEventHandlerRegistration
 
 	// Target informers factory
-	targetInformerFactory TargetInformerFactory
+	targetInformerFactory func(*fedv1b1.KubeFedCluster) (informer, error)
 
 	// Structures returned by targetInformerFactory
 	targetInformers map[string]informer
 
-	// Retrieves configuration to access a cluster.
-	configFactory func(*fedv1b1.KubeFedCluster) (*restclient.Config, error)
-
-	// Caches cluster clients (reduces client discovery and secret retrieval)
-	clusterClients map[string]generic.Client
-
 	// Namespace from which to source KubeFedCluster resources
 	fedNamespace string
 }
@@ -300,24 +303,35 @@ func (f *federatedInformerImpl) Stop() {
 	f.Lock()
 	defer f.Unlock()
 
-	klog.V(4).Infof("... Closing cluster informer channel.")
-	close(f.clusterInformer.stopChan)
+	klog.V(4).Infof("... Removing cluster event handler.")
+	if f.clusterRegistration != nil {
+		if err := f.manager.RemoveClusterEventHandler(f.clusterRegistration); err != nil {
+			klog.Errorf("Failed to remove cluster event handler: %v", err)
+		}
+	}
 	for key, informer := range f.targetInformers {
-		klog.V(4).Infof("... Closing informer channel for %q.", key)
-		close(informer.stopChan)
-		// Remove each informer after it has been stopped to prevent
-		// subsequent cluster deletion from attempting to double close
-		// an informer's stop channel.
+		klog.V(4).Infof("... Releasing informer for %q.", key)
+		informer.release()
+		// Remove each informer after it has been released to prevent
+		// subsequent cluster deletion from attempting to release an
+		// informer twice.
 		delete(f.targetInformers, key)
 	}
+	if f.ownsManager {
+		f.manager.Stop()
+	}
 }
 
 func (f *federatedInformerImpl) Start() {
 	f.Lock()
 	defer f.Unlock()
 
-	f.clusterInformer.stopChan = make(chan struct{})
-	go f.clusterInformer.controller.Run(f.clusterInformer.stopChan)
+	registration, err := f.manager.AddClusterEventHandler(f.clusterHandler)
+	if err != nil {
+		klog.Errorf("Failed to add cluster event handler: %v", err)
+		return
+	}
+	f.clusterRegistration = registration
 }
 
 // GetClientForCluster returns a client for the cluster, if present.
@@ -326,28 +340,17 @@ func (f *federatedInformerImpl) GetClientForCluster(clusterName string) (generic
 	f.Lock()
 	defer f.Unlock()
 
-	// return cached client if one exists (to prevent frequent secret retrieval and rest discovery)
-	if client, ok := f.clusterClients[clusterName]; ok {
-		return client, nil
-	}
-	config, err := f.getConfigForClusterUnlocked(clusterName)
+	// the manager caches clients (to prevent frequent secret retrieval and rest discovery)
+	cluster, err := f.getReadyClusterOrErrorUnlocked(clusterName)
 	if err != nil {
 		return nil, errors.Wrap(err, "Client creation failed")
 	}
-	client, err := generic.New(config)
-	if err != nil {
-		return client, err
-	}
-	f.clusterClients[clusterName] = client
-
-	return client, nil
+	return f.manager.Client(cluster)
 }
 
-func (f *federatedInformerImpl) getConfigForClusterUnlocked(clusterName string) (*restclient.Config, error) {
-	// No locking needed. Will happen in f.GetCluster.
-	klog.V(4).Infof("Getting config for cluster %q", clusterName)
+func (f *federatedInformerImpl) getReadyClusterOrErrorUnlocked(clusterName string) (*fedv1b1.KubeFedCluster, error) {
 	if cluster, found, err := f.getReadyClusterUnlocked(clusterName); found && err == nil {
-		return f.configFactory(cluster)
+		return cluster, nil
 	} else if err != nil {
 		return nil, err
 	}
@@ -358,7 +361,7 @@ func (f *federatedInformerImpl) GetUnreadyClusters() ([]*fedv1b1.KubeFedCluster,
 	f.Lock()
 	defer f.Unlock()
 
-	items := f.clusterInformer.store.List()
+	items := f.clusterStore.List()
 	result := make([]*fedv1b1.KubeFedCluster, 0, len(items))
 	for _, item := range items {
 		if cluster, ok := item.(*fedv1b1.KubeFedCluster); ok {
@@ -387,7 +390,7 @@ func (f *federatedInformerImpl) getClusters(onlyReady bool) ([]*fedv1b1.KubeFedC
 	f.Lock()
 	defer f.Unlock()
 
-	items := f.clusterInformer.store.List()
+	items := f.clusterStore.List()
 	result := make([]*fedv1b1.KubeFedCluster, 0, len(items))
 	for _, item := range items {
 		if cluster, ok := item.(*fedv1b1.KubeFedCluster); ok {
@@ -410,7 +413,7 @@ func (f *federatedInformerImpl) GetReadyCluster(name string) (*fedv1b1.KubeFedCl
 
 func (f *federatedInformerImpl) getReadyClusterUnlocked(name string) (*fedv1b1.KubeFedCluster, bool, error) {
 	key := fmt.Sprintf("%s/%s", f.fedNamespace, name)
-	if obj, exist, err := f.clusterInformer.store.GetByKey(key); exist && err == nil {
+	if obj, exist, err := f.clusterStore.GetByKey(key); exist && err == nil {
 		if cluster, ok := obj.(*fedv1b1.KubeFedCluster); ok {
 			if IsClusterReady(&cluster.Status) {
 				return cluster, true, nil
@@ -425,7 +428,9 @@ func (f *federatedInformerImpl) getReadyClusterUnlocked(name string) (*fedv1b1.K
 
 // Synced returns true if the view is synced (for the first time)
 func (f *federatedInformerImpl) ClustersSynced() bool {
-	return f.clusterInformer.controller.HasSynced()
+	f.Lock()
+	defer f.Unlock()
+	return f.clusterRegistration != nil && f.clusterRegistration.HasSynced()
 }
 
 // Adds the given cluster to federated informer.
@@ -433,24 +438,17 @@ func (f *federatedInformerImpl) addCluster(cluster *fedv1b1.KubeFedCluster) {
 	f.Lock()
 	defer f.Unlock()
 	name := cluster.Name
-	if config, err := f.getConfigForClusterUnlocked(name); err == nil {
-		store, controller, err := f.targetInformerFactory(cluster, config)
-		if err != nil {
-			// TODO: create also an event for cluster.
-			klog.Errorf("Failed to create an informer for cluster %q: %v", cluster.Name, err)
-			return
-		}
-		targetInformer := informer{
-			controller: controller,
-			store:      store,
-			stopChan:   make(chan struct{}),
-		}
-		f.targetInformers[name] = targetInformer
-		go targetInformer.controller.Run(targetInformer.stopChan)
-	} else {
+	if previous, found := f.targetInformers[name]; found {
+		previous.release()
+		delete(f.targetInformers, name)
+	}
+	targetInformer, err := f.targetInformerFactory(cluster)
+	if err != nil {
 		// TODO: create also an event for cluster.
-		klog.Errorf("Failed to create a client for cluster: %v", err)
+		klog.Errorf("Failed to create an informer for cluster %q: %v", cluster.Name, err)
+		return
 	}
+	f.targetInformers[name] = targetInformer
 }
 
 // Removes the cluster from federated informer.
@@ -459,10 +457,9 @@ func (f *federatedInformerImpl) deleteCluster(cluster *fedv1b1.KubeFedCluster) {
 	defer f.Unlock()
 	name := cluster.Name
 	if targetInformer, found := f.targetInformers[name]; found {
-		close(targetInformer.stopChan)
+		targetInformer.release()
 	}
 	delete(f.targetInformers, name)
-	delete(f.clusterClients, name)
 }
 
 // Returns a store created over all stores from target informers.
@@ -546,7 +543,7 @@ func (fs *federatedStoreImpl) ClustersSynced(clusters []*fedv1b1.KubeFedCluster)
 	}
 	for _, cluster := range clusters {
 		if targetInformer, found := fs.federatedInformer.targetInformers[cluster.Name]; found {
-			if !targetInformer.controller.HasSynced() {
+			if !targetInformer.registration.HasSynced() {
 				klog.V(4).Infof("Informer of cluster %q not synced", cluster.Name)
 				return false
 			}
@@ -39,27 +39,56 @@ func NewGenericInformer(config *rest.Config, namespace string, obj runtimeclient
 }
 
 func NewGenericInformerWithEventHandler(config *rest.Config, namespace string, obj runtimeclient.Object, resyncPeriod time.Duration, resourceEventHandlerFuncs *cache.ResourceEventHandlerFuncs) (cache.Store, cache.Controller, error) {
+	listerWatcher, err := newGenericListWatch(config, namespace, obj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Configure InformerOptions with a context-aware ListerWatcher.
+	options := cache.InformerOptions{
+		ListerWatcher: listerWatcher,
+		ObjectType:    obj, // The type of object the informer will cache.
+		Handler:       resourceEventHandlerFuncs,
+		ResyncPeriod:  resyncPeriod,
+	}
+
+	// Create the Informer using the modern Options pattern.
+	store, controller := cache.NewInformerWithOptions(options)
+	return store, controller, nil
+}
+
+// NewGenericSharedInformer returns an informer for the given type
+// whose store and watch can be shared by multiple event handlers.
+func NewGenericSharedInformer(config *rest.Config, namespace string, obj runtimeclient.Object, resyncPeriod time.Duration) (cache.SharedIndexInformer, error) {
+	listerWatcher, err := newGenericListWatch(config, namespace, obj)
+	if err != nil {
+		return nil, err
+	}
+	return cache.NewSharedIndexInformer(listerWatcher, obj, resyncPeriod, cache.Indexers{}), nil
+}
+
+func newGenericListWatch(config *rest.Config, namespace string, obj runtimeclient.Object) (cache.ListerWatcher, error) {
 	// Extract GroupVersionKind (GVK) from the provided runtime object.
 	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to get GVK for object")
+		return nil, errors.Wrap(err, "failed to get GVK for object")
 	}
 
 	// Initialize an HTTP Client based on the REST config.
 	httpClient, err := rest.HTTPClientFor(config)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to create HTTP client")
+		return nil, errors.Wrap(err, "failed to create HTTP client")
 	}
 
 	// Build a RESTMapper to resolve GVK to a RESTMapping.
 	mapper, err := apiutil.NewDynamicRESTMapper(config, httpClient)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "could not create RESTMapper")
+		return nil, errors.Wrap(err, "could not create RESTMapper")
 	}
 
 	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to get REST mapping")
+		return nil, errors.Wrap(err, "failed to get REST mapping")
 	}
 
 	// Create a GVK-specific REST Client.
@@ -73,50 +102,40 @@ func NewGenericInformerWithEventHandler(config *rest.Config, namespace string, o
 		httpClient,
 	)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to create REST client")
+		return nil, errors.Wrap(err, "failed to create REST client")
 	}
 
 	// Instantiate the List object type required for unmarshaling API responses.
 	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
 	listObj, err := scheme.Scheme.New(listGVK)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "failed to instantiate list object for %s", listGVK)
+		return nil, errors.Wrapf(err, "failed to instantiate list object for %s", listGVK)
 	}
 
-	// Configure InformerOptions with a context-aware ListerWatcher.
-	options := cache.InformerOptions{
+	return &cache.ListWatch{
 		// ListWithContextFunc is the modern replacement for ListFunc.
-		ListerWatcher: &cache.ListWatch{
-			ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (pkgruntime.Object, error) {
-				results := listObj.DeepCopyObject()
-				// Only apply namespace filtering if the resource is namespaced and a namespace is provided.
-				isNamespaceScoped := namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot
-
-				err := restClient.Get().
-					NamespaceIfScoped(namespace, isNamespaceScoped).
-					Resource(mapping.Resource.Resource).
-					VersionedParams(&opts, scheme.ParameterCodec).
-					Do(ctx).
-					Into(results)
-				return results, err
-			},
-			// WatchWithContextFunc is the modern replacement for WatchFunc.
-			WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
-				opts.Watch = true
-				isNamespaceScoped := namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot
-				return restClient.Get().
-					NamespaceIfScoped(namespace, isNamespaceScoped).
-					Resource(mapping.Resource.Resource).
-					VersionedParams(&opts, scheme.ParameterCodec).
-					Watch(ctx)
-			},
+		ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (pkgruntime.Object, error) {
+			results := listObj.DeepCopyObject()
+			// Only apply namespace filtering if the resource is namespaced and a namespace is provided.
+			isNamespaceScoped := namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot
+
+			err := restClient.Get().
+				NamespaceIfScoped(namespace, isNamespaceScoped).
+				Resource(mapping.Resource.Resource).
+				VersionedParams(&opts, scheme.ParameterCodec).
+				Do(ctx).
+				Into(results)
+			return results, err
 		},
-		ObjectType:   obj, // The type of object the informer will cache.
-		Handler:      resourceEventHandlerFuncs,
-		ResyncPeriod: resyncPeriod,
-	}
-
-	// Create the Informer using the modern Options pattern.
-	store, controller := cache.NewInformerWithOptions(options)
-	return store, controller, nil
+		// WatchWithContextFunc is the modern replacement for WatchFunc.
+		WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			opts.Watch = true
+			isNamespaceScoped := namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot
+			return restClient.Get().
+				NamespaceIfScoped(namespace, isNamespaceScoped).
+				Resource(mapping.Resource.Resource).
+				VersionedParams(&opts, scheme.ParameterCodec).
+				Watch(ctx)
+		},
+	}, nil
 }
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// InheritNamespacePlacementAnnotation determines whether a
+	// namespaced federated resource without placement inherits the
+	// placement of its federated namespace.  The annotation can be set
+	// to "true" or "false" on the federated resource or on the
+	// federated namespace, and the annotation of the resource takes
+	// precedence.  If neither is annotated, the KubeFed configuration
+	// applies.
+	InheritNamespacePlacementAnnotation = "kubefed.io/inherit-namespace-placement"
+)
+
+// InheritsNamespacePlacement determines whether the given federated
+// resource inherits the placement of the given federated namespace
+// when it does not specify placement of its own.
+func InheritsNamespacePlacement(resource, fedNamespace *unstructured.Unstructured, enabledByDefault bool) bool {
+	if fedNamespace == nil {
+		return false
+	}
+	for _, obj := range []*unstructured.Unstructured{resource, fedNamespace} {
+		value, ok := obj.GetAnnotations()[InheritNamespacePlacementAnnotation]
+		if !ok {
+			continue
+		}
+		if inherit, err := strconv.ParseBool(value); err == nil {
+			return inherit
+		}
+	}
+	return enabledByDefault
+}
+
+// HasPlacement checks whether the given federated resource specifies
+// placement, either directly or by referencing a PlacementPolicy.
+func HasPlacement(obj *unstructured.Unstructured) bool {
+	for _, field := range []string{PlacementField, PlacementRefField} {
+		if _, ok, _ := unstructured.NestedFieldNoCopy(obj.Object, SpecField, field); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// InheritNamespacePlacement returns a copy of the given federated
+// resource whose placement is the placement of the given federated
+// namespace.
+func InheritNamespacePlacement(resource, fedNamespace *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	placement, ok, err := unstructured.NestedMap(fedNamespace.Object, SpecField, PlacementField)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve placement of federated namespace")
+	}
+	result := resource.DeepCopy()
+	if !ok {
+		return result, nil
+	}
+	err = unstructured.SetNestedMap(result.Object, placement, SpecField, PlacementField)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set placement")
+	}
+	return result, nil
+}
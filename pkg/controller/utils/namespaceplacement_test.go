@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestInheritsNamespacePlacement(t *testing.T) {
+	testCases := map[string]struct {
+		resourceAnnotation  string
+		namespaceAnnotation string
+		noNamespace         bool
+		enabledByDefault    bool
+		expected            bool
+	}{
+		"disabled by default": {},
+		"enabled by default": {
+			enabledByDefault: true,
+			expected:         true,
+		},
+		"enabled by namespace annotation": {
+			namespaceAnnotation: "true",
+			expected:            true,
+		},
+		"resource annotation takes precedence": {
+			resourceAnnotation:  "false",
+			namespaceAnnotation: "true",
+			enabledByDefault:    true,
+		},
+		"invalid annotation is ignored": {
+			resourceAnnotation: "maybe",
+			enabledByDefault:   true,
+			expected:           true,
+		},
+		"nothing to inherit without federated namespace": {
+			resourceAnnotation: "true",
+			noNamespace:        true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			resource := newAnnotatedObject(testCase.resourceAnnotation)
+			fedNamespace := newAnnotatedObject(testCase.namespaceAnnotation)
+			if testCase.noNamespace {
+				fedNamespace = nil
+			}
+			inherits := InheritsNamespacePlacement(resource, fedNamespace, testCase.enabledByDefault)
+			if inherits != testCase.expected {
+				t.Errorf("Expected %v, got %v", testCase.expected, inherits)
+			}
+		})
+	}
+}
+
+func TestInheritNamespacePlacement(t *testing.T) {
+	clusters := []*fedv1b1.KubeFedCluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster2"}},
+	}
+	fedNamespace := newAnnotatedObject("")
+	if err := SetClusterNames(fedNamespace, []string{"cluster2"}); err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+
+	resource := newAnnotatedObject("")
+	if HasPlacement(resource) {
+		t.Fatalf("Expected resource to have no placement")
+	}
+	selectedClusters, err := ComputeNamespacedPlacement(resource, fedNamespace, clusters, false, false)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if selectedClusters.Len() != 0 {
+		t.Fatalf("Expected no clusters without inheritance, got %v", sets.List(selectedClusters))
+	}
+
+	inherited, err := InheritNamespacePlacement(resource, fedNamespace)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if !HasPlacement(inherited) {
+		t.Fatalf("Expected inherited placement")
+	}
+	if HasPlacement(resource) {
+		t.Fatalf("Expected original resource to be unmodified")
+	}
+	selectedClusters, err = ComputeNamespacedPlacement(inherited, fedNamespace, clusters, false, false)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	expectedClusters := sets.New[string]("cluster2")
+	if !selectedClusters.Equal(expectedClusters) {
+		t.Fatalf("Expected clusters %v, got %v", sets.List(expectedClusters), sets.List(selectedClusters))
+	}
+}
+
+func newAnnotatedObject(inheritAnnotation string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			SpecField: map[string]interface{}{},
+		},
+	}
+	if inheritAnnotation != "" {
+		obj.SetAnnotations(map[string]string{
+			InheritNamespacePlacementAnnotation: inheritAnnotation,
+		})
+	}
+	return obj
+}
@@ -18,24 +18,60 @@ package utils
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/pkg/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 )
 
 type ClusterOverride struct {
 	Op    string      `json:"op,omitempty"`
-	Path  string      `json:"path"`
+	Path  string      `json:"path,omitempty"`
 	Value interface{} `json:"value,omitempty"`
+	// Type determines how the override is applied.  Op, path and
+	// value are applied as a JSON patch operation by default, while
+	// the patch document is applied for the other types.
+	Type  string      `json:"type,omitempty"`
+	Patch interface{} `json:"patch,omitempty"`
+	// Template indicates that the strings of value or patch are
+	// templates to be expanded for each cluster.  Strings are used
+	// verbatim otherwise.
+	Template bool `json:"template,omitempty"`
 }
 
+const (
+	// JSONPatchOverrideType applies an RFC 6902 JSON patch operation.
+	JSONPatchOverrideType = "JSONPatch"
+	// StrategicMergeOverrideType applies a strategic merge patch using
+	// the schema of the target type if it is known, and a JSON merge
+	// patch otherwise.
+	StrategicMergeOverrideType = "StrategicMerge"
+	// MergePatchOverrideType applies an RFC 7386 JSON merge patch.
+	MergePatchOverrideType = "MergePatch"
+)
+
+// IsPatchDocument checks whether the override applies a patch
+// document rather than a JSON patch operation.
+func (o *ClusterOverride) IsPatchDocument() bool {
+	return o.Type == StrategicMergeOverrideType || o.Type == MergePatchOverrideType
+}
+
+// GenericOverrideItem holds the overrides for either the cluster
+// with the given name or the clusters matching the given selector.
 type GenericOverrideItem struct {
-	ClusterName      string            `json:"clusterName"`
-	ClusterOverrides []ClusterOverride `json:"clusterOverrides,omitempty"`
+	ClusterName      string                `json:"clusterName,omitempty"`
+	ClusterSelector  *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	ClusterOverrides []ClusterOverride     `json:"clusterOverrides,omitempty"`
 }
 
 type GenericOverrideSpec struct {
@@ -88,12 +124,167 @@ func (m OverridesMap) ToUnstructuredSlice() []interface{} {
 }
 
 // GetOverrides returns a map of overrides populated from the given
-// unstructured object.
+// unstructured object.  Only overrides for named clusters are
+// included, see ResolveOverrides for overrides that also account for
+// cluster selectors.
 func GetOverrides(rawObj *unstructured.Unstructured) (OverridesMap, error) {
 	overridesMap := make(OverridesMap)
 
+	overrideItems, err := getOverrideItems(rawObj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, overrideItem := range overrideItems {
+		if overrideItem.ClusterSelector != nil {
+			continue
+		}
+		clusterName := overrideItem.ClusterName
+		if _, ok := overridesMap[clusterName]; ok {
+			return nil, errors.Errorf("cluster %q appears more than once", clusterName)
+		}
+		overridesMap[clusterName] = overrideItem.ClusterOverrides
+	}
+
+	return overridesMap, nil
+}
+
+// SelectorOverride holds the overrides for the clusters matching a
+// label selector.
+type SelectorOverride struct {
+	Selector labels.Selector
+	// Specificity is the number of requirements of the selector.
+	Specificity      int
+	ClusterOverrides ClusterOverrides
+}
+
+// GetSelectorOverrides returns the overrides for cluster selectors of
+// the given unstructured object in order of precedence.  A more
+// specific selector takes precedence over a less specific one, and
+// among equally specific selectors the one listed first takes
+// precedence.
+func GetSelectorOverrides(rawObj *unstructured.Unstructured) ([]SelectorOverride, error) {
+	overrideItems, err := getOverrideItems(rawObj)
+	if err != nil {
+		return nil, err
+	}
+
+	var selectorOverrides []SelectorOverride
+	for i, overrideItem := range overrideItems {
+		if overrideItem.ClusterSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(overrideItem.ClusterSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "overrides[%d] has an invalid cluster selector", i)
+		}
+		selectorOverrides = append(selectorOverrides, SelectorOverride{
+			Selector:         selector,
+			Specificity:      len(overrideItem.ClusterSelector.MatchLabels) + len(overrideItem.ClusterSelector.MatchExpressions),
+			ClusterOverrides: overrideItem.ClusterOverrides,
+		})
+	}
+	sort.SliceStable(selectorOverrides, func(i, j int) bool {
+		return selectorOverrides[i].Specificity > selectorOverrides[j].Specificity
+	})
+	return selectorOverrides, nil
+}
+
+// ResolveOverrides returns a map of the overrides that apply to each
+// of the given clusters.  The overrides for a cluster combine the
+// overrides for its name with the overrides of every selector
+// matching its labels.  If more than one of these target the same
+// path, only the one with the highest precedence applies: overrides
+// for the cluster name take precedence over selector overrides, which
+// take precedence in the order returned by GetSelectorOverrides.
+// Overrides are ordered from lowest to highest precedence so that
+// overrides with a higher precedence are applied last.  The values of
+// templated overrides are expanded for each of the clusters.
+func ResolveOverrides(rawObj *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster) (OverridesMap, error) {
+	overridesMap, err := GetOverrides(rawObj)
+	if err != nil {
+		return nil, err
+	}
+	selectorOverrides, err := GetSelectorOverrides(rawObj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		clusterOverrides := mergeSelectorOverrides(overridesMap[cluster.Name], selectorOverrides, labels.Set(cluster.GetLabels()))
+		if clusterOverrides == nil {
+			continue
+		}
+		clusterOverrides, err = ExpandOverrides(clusterOverrides, cluster)
+		if err != nil {
+			return nil, err
+		}
+		overridesMap[cluster.Name] = clusterOverrides
+	}
+
+	return overridesMap, nil
+}
+
+// mergeSelectorOverrides combines the overrides for the name of a
+// cluster with the overrides of the selectors matching its labels.
+func mergeSelectorOverrides(nameOverrides ClusterOverrides, selectorOverrides []SelectorOverride, clusterLabels labels.Set) ClusterOverrides {
+	// Patch documents have no path and never conflict.
+	paths := sets.NewString()
+	for _, clusterOverride := range nameOverrides {
+		if !clusterOverride.IsPatchDocument() {
+			paths.Insert(clusterOverride.Path)
+		}
+	}
+
+	var selectorLayers []ClusterOverrides
+	for _, selectorOverride := range selectorOverrides {
+		if !selectorOverride.Selector.Matches(clusterLabels) {
+			continue
+		}
+		var layer ClusterOverrides
+		for _, clusterOverride := range selectorOverride.ClusterOverrides {
+			if clusterOverride.IsPatchDocument() || !paths.Has(clusterOverride.Path) {
+				layer = append(layer, clusterOverride)
+			}
+		}
+		for _, clusterOverride := range layer {
+			if !clusterOverride.IsPatchDocument() {
+				paths.Insert(clusterOverride.Path)
+			}
+		}
+		selectorLayers = append(selectorLayers, layer)
+	}
+	if len(selectorLayers) == 0 {
+		return nameOverrides
+	}
+
+	var clusterOverrides ClusterOverrides
+	for i := len(selectorLayers) - 1; i >= 0; i-- {
+		clusterOverrides = append(clusterOverrides, selectorLayers[i]...)
+	}
+	return append(clusterOverrides, nameOverrides...)
+}
+
+// HasSelectorOverrides checks whether the given unstructured object
+// defines overrides for cluster selectors.
+func HasSelectorOverrides(rawObj *unstructured.Unstructured) (bool, error) {
+	overrideItems, err := getOverrideItems(rawObj)
+	if err != nil {
+		return false, err
+	}
+	for _, overrideItem := range overrideItems {
+		if overrideItem.ClusterSelector != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getOverrideItems returns the validated override items of the given
+// unstructured object.
+func getOverrideItems(rawObj *unstructured.Unstructured) ([]GenericOverrideItem, error) {
 	if rawObj == nil {
-		return overridesMap, nil
+		return nil, nil
 	}
 
 	genericFedObject := GenericOverride{}
@@ -104,36 +295,92 @@ func GetOverrides(rawObj *unstructured.Unstructured) (OverridesMap, error) {
 
 	if genericFedObject.Spec == nil || genericFedObject.Spec.Overrides == nil {
 		// No overrides defined for the federated type
-		return overridesMap, nil
+		return nil, nil
 	}
 
-	for _, overrideItem := range genericFedObject.Spec.Overrides {
-		clusterName := overrideItem.ClusterName
-		if _, ok := overridesMap[clusterName]; ok {
-			return nil, errors.Errorf("cluster %q appears more than once", clusterName)
+	for i, overrideItem := range genericFedObject.Spec.Overrides {
+		target := overrideItem.ClusterName
+		switch {
+		case overrideItem.ClusterSelector != nil && target != "":
+			return nil, errors.Errorf("overrides[%d] must not specify both clusterName and clusterSelector", i)
+		case overrideItem.ClusterSelector != nil:
+			target = metav1.FormatLabelSelector(overrideItem.ClusterSelector)
 		}
 
-		clusterOverrides := overrideItem.ClusterOverrides
-
 		paths := sets.NewString()
-		for i, clusterOverride := range clusterOverrides {
+		for j, clusterOverride := range overrideItem.ClusterOverrides {
+			switch clusterOverride.Type {
+			case "", JSONPatchOverrideType:
+			case StrategicMergeOverrideType, MergePatchOverrideType:
+				if err := validatePatchDocument(clusterOverride); err != nil {
+					return nil, errors.Wrapf(err, "override[%d] for cluster %q has an invalid patch", j, target)
+				}
+				continue
+			default:
+				return nil, errors.Errorf("override[%d] for cluster %q has an unsupported type: %s", j, target, clusterOverride.Type)
+			}
 			path := clusterOverride.Path
+			if path == "" {
+				return nil, errors.Errorf("override[%d] for cluster %q has no path", j, target)
+			}
 			if invalidPaths.Has(path) {
-				return nil, errors.Errorf("override[%d] for cluster %q has an invalid path: %s", i, clusterName, path)
+				return nil, errors.Errorf("override[%d] for cluster %q has an invalid path: %s", j, target, path)
 			}
 			if paths.Has(path) {
-				return nil, errors.Errorf("path %q appears more than once for cluster %q", path, clusterName)
+				return nil, errors.Errorf("path %q appears more than once for cluster %q", path, target)
 			}
 			paths.Insert(path)
 		}
-		overridesMap[clusterName] = clusterOverrides
 	}
 
-	return overridesMap, nil
+	return genericFedObject.Spec.Overrides, nil
+}
+
+// validatePatchDocument checks that the patch document of the given
+// override is an object that does not modify any of the fields that
+// may not be overridden, either directly or by deleting or replacing
+// an object containing them.
+func validatePatchDocument(clusterOverride ClusterOverride) error {
+	if clusterOverride.Path != "" || clusterOverride.Op != "" || clusterOverride.Value != nil {
+		return errors.Errorf("op, path and value may not be set for type %s", clusterOverride.Type)
+	}
+	patch, ok := clusterOverride.Patch.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("patch must be an object for type %s", clusterOverride.Type)
+	}
+	for path := range invalidPaths {
+		fields := strings.Split(strings.TrimPrefix(path, "/"), "/")
+		if _, found, _ := unstructured.NestedFieldNoCopy(patch, fields...); found {
+			return errors.Errorf("patch may not modify %s", path)
+		}
+		// A parent object that is set to null or to a value that is
+		// not an object removes the field.
+		for i := 1; i < len(fields); i++ {
+			parent, found, _ := unstructured.NestedFieldNoCopy(patch, fields[:i]...)
+			if !found {
+				break
+			}
+			parentObj, ok := parent.(map[string]interface{})
+			if !ok {
+				return errors.Errorf("patch may not replace /%s, which contains %s", strings.Join(fields[:i], "/"), path)
+			}
+			// Directives of a strategic merge patch, e.g. $patch:
+			// replace or $retainKeys, may remove the field as well.
+			if clusterOverride.Type == StrategicMergeOverrideType {
+				for key := range parentObj {
+					if strings.HasPrefix(key, "$") {
+						return errors.Errorf("patch may not use directive %s in /%s, which contains %s", key, strings.Join(fields[:i], "/"), path)
+					}
+				}
+			}
+		}
+	}
+	return nil
 }
 
 // SetOverrides sets the spec.overrides field of the unstructured
-// object from the provided overrides map.
+// object from the provided overrides map.  Existing overrides for
+// cluster selectors are retained.
 func SetOverrides(fedObject *unstructured.Unstructured, overridesMap OverridesMap) error {
 	rawSpec := fedObject.Object[SpecField]
 	if rawSpec == nil {
@@ -145,7 +392,14 @@ func SetOverrides(fedObject *unstructured.Unstructured, overridesMap OverridesMa
 	if !ok {
 		return errors.Errorf("Unable to set overrides since %q is not an object: %T", SpecField, rawSpec)
 	}
-	spec[OverridesField] = overridesMap.ToUnstructuredSlice()
+	overrides := overridesMap.ToUnstructuredSlice()
+	existingOverrides, _ := spec[OverridesField].([]interface{})
+	for _, rawItem := range existingOverrides {
+		if item, ok := rawItem.(map[string]interface{}); ok && item[ClusterSelectorField] != nil {
+			overrides = append(overrides, item)
+		}
+	}
+	spec[OverridesField] = overrides
 	return nil
 }
 
@@ -159,6 +413,61 @@ func UnstructuredToInterface(rawObj *unstructured.Unstructured, obj interface{})
 	return json.Unmarshal(content, obj)
 }
 
+// ApplyClusterOverrides applies the given overrides in order to the
+// given unstructured object.  Consecutive JSON patch operations are
+// applied as a single JSON patch.
+func ApplyClusterOverrides(obj *unstructured.Unstructured, overrides ClusterOverrides) error {
+	var jsonPatch ClusterOverrides
+	for i := range overrides {
+		if !overrides[i].IsPatchDocument() {
+			jsonPatch = append(jsonPatch, overrides[i])
+			continue
+		}
+		if len(jsonPatch) > 0 {
+			if err := ApplyJSONPatch(obj, jsonPatch); err != nil {
+				return err
+			}
+			jsonPatch = nil
+		}
+		if err := applyPatchDocument(obj, overrides[i]); err != nil {
+			return err
+		}
+	}
+	if len(jsonPatch) > 0 {
+		return ApplyJSONPatch(obj, jsonPatch)
+	}
+	return nil
+}
+
+// applyPatchDocument applies the patch document of the given
+// override to the given unstructured object.  A strategic merge patch
+// requires the schema of the object's type, which is only known for
+// built-in types, and is applied as a JSON merge patch otherwise.
+func applyPatchDocument(obj *unstructured.Unstructured, override ClusterOverride) error {
+	patchBytes, err := json.Marshal(override.Patch)
+	if err != nil {
+		return err
+	}
+
+	objectJSONBytes, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	var patchedObjectJSONBytes []byte
+	dataStruct, schemaErr := scheme.Scheme.New(obj.GroupVersionKind())
+	if override.Type == StrategicMergeOverrideType && schemaErr == nil {
+		patchedObjectJSONBytes, err = strategicpatch.StrategicMergePatch(objectJSONBytes, patchBytes, dataStruct)
+	} else {
+		patchedObjectJSONBytes, err = jsonpatch.MergePatch(objectJSONBytes, patchBytes)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to apply %s patch", override.Type)
+	}
+
+	return obj.UnmarshalJSON(patchedObjectJSONBytes)
+}
+
 // ApplyJSONPatch applies the override on to the given unstructured object.
 func ApplyJSONPatch(obj *unstructured.Unstructured, overrides ClusterOverrides) error {
 	// TODO: Do the defaulting of "op" field to "replace" in API defaulting
@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestResolveOverrides(t *testing.T) {
+	clusters := []*fedv1b1.KubeFedCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "cluster1",
+				Labels: map[string]string{"env": "prod", "tier": "gold"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "cluster2",
+				Labels: map[string]string{"env": "prod"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster3",
+			},
+		},
+	}
+	overrides := []interface{}{
+		map[string]interface{}{
+			ClusterSelectorField: map[string]interface{}{
+				MatchLabelsField: map[string]interface{}{"env": "prod"},
+			},
+			ClusterOverridesField: []interface{}{
+				map[string]interface{}{"path": "/spec/replicas", "value": int64(3)},
+				map[string]interface{}{"path": "/spec/paused", "value": true},
+			},
+		},
+		map[string]interface{}{
+			ClusterSelectorField: map[string]interface{}{
+				MatchLabelsField: map[string]interface{}{"env": "prod", "tier": "gold"},
+			},
+			ClusterOverridesField: []interface{}{
+				map[string]interface{}{"path": "/spec/replicas", "value": int64(5)},
+			},
+		},
+		map[string]interface{}{
+			ClusterNameField: "cluster1",
+			ClusterOverridesField: []interface{}{
+				map[string]interface{}{"path": "/spec/paused", "value": false},
+			},
+		},
+	}
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			SpecField: map[string]interface{}{
+				OverridesField: overrides,
+			},
+		},
+	}
+
+	overridesMap, err := ResolveOverrides(obj, clusters)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	expected := OverridesMap{
+		"cluster1": ClusterOverrides{
+			{Path: "/spec/replicas", Value: float64(5)},
+			{Path: "/spec/paused", Value: false},
+		},
+		"cluster2": ClusterOverrides{
+			{Path: "/spec/replicas", Value: float64(3)},
+			{Path: "/spec/paused", Value: true},
+		},
+	}
+	if !reflect.DeepEqual(overridesMap, expected) {
+		t.Fatalf("Expected overrides %#v, got %#v", expected, overridesMap)
+	}
+
+	namedOverrides, err := GetOverrides(obj)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if len(namedOverrides) != 1 || namedOverrides["cluster1"] == nil {
+		t.Fatalf("Expected only overrides for cluster1, got %#v", namedOverrides)
+	}
+
+	err = SetOverrides(obj, namedOverrides)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	selectorOverrides, err := GetSelectorOverrides(obj)
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if len(selectorOverrides) != 2 {
+		t.Fatalf("Expected overrides for cluster selectors to be retained, got %d", len(selectorOverrides))
+	}
+}
+
+func TestGetOverridesInvalidItem(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			SpecField: map[string]interface{}{
+				OverridesField: []interface{}{
+					map[string]interface{}{
+						ClusterNameField:     "cluster1",
+						ClusterSelectorField: map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+	if _, err := GetOverrides(obj); err == nil {
+		t.Fatalf("Expected an error for an item with both clusterName and clusterSelector")
+	}
+}
+
+func TestApplyClusterOverrides(t *testing.T) {
+	newDeployment := func(apiVersion string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": apiVersion,
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name": "foo",
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(1),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "sidecar", "image": "sidecar:1"},
+								map[string]interface{}{"name": "app", "image": "app:1"},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	imagePatch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "app:2"},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		apiVersion         string
+		overrides          ClusterOverrides
+		expectedContainers int
+		expectedImage      string
+		expectedReplicas   int64
+	}{
+		"strategic merge patches containers by name": {
+			apiVersion: "apps/v1",
+			overrides: ClusterOverrides{
+				{Path: "/spec/replicas", Value: 3},
+				{Type: StrategicMergeOverrideType, Patch: imagePatch},
+			},
+			expectedContainers: 2,
+			expectedImage:      "app:2",
+			expectedReplicas:   3,
+		},
+		"merge patch replaces lists": {
+			apiVersion: "apps/v1",
+			overrides: ClusterOverrides{
+				{Type: MergePatchOverrideType, Patch: imagePatch},
+				{Path: "/spec/replicas", Value: 2},
+			},
+			expectedContainers: 1,
+			expectedImage:      "app:2",
+			expectedReplicas:   2,
+		},
+		"strategic merge of unknown type falls back to merge patch": {
+			apiVersion: "example.com/v1",
+			overrides: ClusterOverrides{
+				{Type: StrategicMergeOverrideType, Patch: imagePatch},
+			},
+			expectedContainers: 1,
+			expectedImage:      "app:2",
+			expectedReplicas:   1,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := newDeployment(testCase.apiVersion)
+			if err := ApplyClusterOverrides(obj, testCase.overrides); err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+			if len(containers) != testCase.expectedContainers {
+				t.Fatalf("Expected %d containers, got %d", testCase.expectedContainers, len(containers))
+			}
+			image := containers[len(containers)-1].(map[string]interface{})["image"]
+			if image != testCase.expectedImage {
+				t.Errorf("Expected image %q, got %q", testCase.expectedImage, image)
+			}
+			replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+			if replicas != testCase.expectedReplicas {
+				t.Errorf("Expected %d replicas, got %d", testCase.expectedReplicas, replicas)
+			}
+		})
+	}
+}
+
+func TestGetOverridesInvalidPatch(t *testing.T) {
+	testCases := map[string]map[string]interface{}{
+		"unsupported type": {
+			"type":  "Unknown",
+			"patch": map[string]interface{}{},
+		},
+		"patch is not an object": {
+			"type":  MergePatchOverrideType,
+			"patch": "foo",
+		},
+		"patch with path": {
+			"type":  MergePatchOverrideType,
+			"path":  "/spec",
+			"patch": map[string]interface{}{},
+		},
+		"JSON patch without path": {
+			"value": "foo",
+		},
+		"typed JSON patch without path": {
+			"type": JSONPatchOverrideType,
+			"op":   "remove",
+		},
+		"patch modifies name": {
+			"type": StrategicMergeOverrideType,
+			"patch": map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "bar"},
+			},
+		},
+		"patch deletes metadata": {
+			"type": MergePatchOverrideType,
+			"patch": map[string]interface{}{
+				"metadata": nil,
+			},
+		},
+		"patch replaces metadata with a non-object": {
+			"type": StrategicMergeOverrideType,
+			"patch": map[string]interface{}{
+				"metadata": "foo",
+			},
+		},
+		"patch replaces metadata with a directive": {
+			"type": StrategicMergeOverrideType,
+			"patch": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"$patch": "replace",
+					"labels": map[string]interface{}{"foo": "bar"},
+				},
+			},
+		},
+	}
+
+	for testName, clusterOverride := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					SpecField: map[string]interface{}{
+						OverridesField: []interface{}{
+							map[string]interface{}{
+								ClusterNameField:      "cluster1",
+								ClusterOverridesField: []interface{}{clusterOverride},
+							},
+						},
+					},
+				},
+			}
+			if _, err := GetOverrides(obj); err == nil {
+				t.Fatalf("Expected an error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+const (
+	// templateDelimiter starts the actions of a template.  Strings of
+	// a templated override that do not contain it need not be parsed.
+	templateDelimiter = "{{"
+
+	// maxExpandedValueLength is the maximum length in bytes of a
+	// string expanded from a template, which matches the maximum
+	// total size of the annotations of an object.
+	maxExpandedValueLength = 256 * 1024
+)
+
+// OverrideTemplateData is the data available to templated override
+// values, e.g. "{{ .Cluster.Name }}-ingress.example.com".
+type OverrideTemplateData struct {
+	Cluster OverrideTemplateCluster
+}
+
+// OverrideTemplateCluster exposes the metadata of the cluster an
+// override value is expanded for.
+type OverrideTemplateCluster struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+	Status      OverrideTemplateClusterStatus
+}
+
+// OverrideTemplateClusterStatus exposes the discovered topology of the
+// cluster an override value is expanded for.
+type OverrideTemplateClusterStatus struct {
+	Region string
+	Zones  []string
+}
+
+// NewOverrideTemplateData returns the template data for the given
+// cluster.
+func NewOverrideTemplateData(cluster *fedv1b1.KubeFedCluster) *OverrideTemplateData {
+	data := &OverrideTemplateData{
+		Cluster: OverrideTemplateCluster{
+			Name:        cluster.Name,
+			Labels:      cluster.GetLabels(),
+			Annotations: cluster.GetAnnotations(),
+			Status: OverrideTemplateClusterStatus{
+				Zones: cluster.Status.Zones,
+			},
+		},
+	}
+	if cluster.Status.Region != nil {
+		data.Cluster.Status.Region = *cluster.Status.Region
+	}
+	return data
+}
+
+// HasTemplatedOverrides checks whether any override of the given
+// unstructured object is marked as a template and has a value or patch
+// to expand.
+func HasTemplatedOverrides(rawObj *unstructured.Unstructured) (bool, error) {
+	overrideItems, err := getOverrideItems(rawObj)
+	if err != nil {
+		return false, err
+	}
+	for _, overrideItem := range overrideItems {
+		for _, clusterOverride := range overrideItem.ClusterOverrides {
+			if clusterOverride.Template && (isTemplatedValue(clusterOverride.Value) || isTemplatedValue(clusterOverride.Patch)) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// ExpandOverrides returns a copy of the given overrides whose string
+// values and patch documents are expanded for the given cluster if the
+// override is marked as a template.  Other overrides are unchanged.
+// Templates may only contain text and actions that output a field of
+// OverrideTemplateData, an element of such a field selected with
+// index, or a string constant.  A missing label or annotation expands
+// to an empty string.
+func ExpandOverrides(overrides ClusterOverrides, cluster *fedv1b1.KubeFedCluster) (ClusterOverrides, error) {
+	data := NewOverrideTemplateData(cluster)
+	expanded := make(ClusterOverrides, len(overrides))
+	for i, clusterOverride := range overrides {
+		expanded[i] = clusterOverride
+		if !clusterOverride.Template {
+			continue
+		}
+		value, err := expandValue(clusterOverride.Value, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to expand the value of path %q for cluster %q", clusterOverride.Path, cluster.Name)
+		}
+		patch, err := expandValue(clusterOverride.Patch, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to expand the %s patch for cluster %q", clusterOverride.Type, cluster.Name)
+		}
+		expanded[i].Value = value
+		expanded[i].Patch = patch
+	}
+	return expanded, nil
+}
+
+func expandValue(value interface{}, data *OverrideTemplateData) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case string:
+		if !strings.Contains(typedValue, templateDelimiter) {
+			return typedValue, nil
+		}
+		tmpl, err := template.New("override").Option("missingkey=zero").Parse(typedValue)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateTemplate(tmpl); err != nil {
+			return nil, err
+		}
+		result := &limitedBuilder{limit: maxExpandedValueLength}
+		if err := tmpl.Execute(result, data); err != nil {
+			return nil, err
+		}
+		return result.String(), nil
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(typedValue))
+		for key, element := range typedValue {
+			expandedElement, err := expandValue(element, data)
+			if err != nil {
+				return nil, err
+			}
+			expanded[key] = expandedElement
+		}
+		return expanded, nil
+	case []interface{}:
+		expanded := make([]interface{}, len(typedValue))
+		for i, element := range typedValue {
+			expandedElement, err := expandValue(element, data)
+			if err != nil {
+				return nil, err
+			}
+			expanded[i] = expandedElement
+		}
+		return expanded, nil
+	default:
+		return value, nil
+	}
+}
+
+// validateTemplate ensures that the given template only contains
+// text and actions that output data or string constants.  Control
+// structures, variables, pipelines and function calls other than
+// index are rejected so that the expansion of a template is cheap and
+// its output bounded by the size of the data.
+func validateTemplate(tmpl *template.Template) error {
+	for _, associated := range tmpl.Templates() {
+		if associated.Name() != tmpl.Name() {
+			return errors.Errorf("template definitions are not allowed")
+		}
+	}
+	for _, node := range tmpl.Tree.Root.Nodes {
+		switch typedNode := node.(type) {
+		case *parse.TextNode:
+		case *parse.ActionNode:
+			if err := validateTemplatePipe(typedNode.Pipe); err != nil {
+				return errors.Wrapf(err, "invalid action %q", typedNode.String())
+			}
+		default:
+			return errors.Errorf("%q is not allowed, only data can be referenced", node.String())
+		}
+	}
+	return nil
+}
+
+func validateTemplatePipe(pipe *parse.PipeNode) error {
+	if len(pipe.Decl) != 0 {
+		return errors.New("variables are not allowed")
+	}
+	if len(pipe.Cmds) != 1 {
+		return errors.New("pipelines are not allowed")
+	}
+	args := pipe.Cmds[0].Args
+	if len(args) == 1 {
+		if _, ok := args[0].(*parse.StringNode); ok {
+			return nil
+		}
+		return validateTemplateField(args[0])
+	}
+	if identifier, ok := args[0].(*parse.IdentifierNode); !ok || identifier.Ident != "index" {
+		return errors.New("only the function index may be called")
+	}
+	if len(args) < 3 {
+		return errors.New("index requires a field and at least one key")
+	}
+	if err := validateTemplateField(args[1]); err != nil {
+		return err
+	}
+	for _, arg := range args[2:] {
+		switch arg.(type) {
+		case *parse.StringNode, *parse.NumberNode:
+		default:
+			return errors.Errorf("key %q of index must be a constant", arg.String())
+		}
+	}
+	return nil
+}
+
+func validateTemplateField(node parse.Node) error {
+	field, ok := node.(*parse.FieldNode)
+	if !ok || len(field.Ident) < 2 || field.Ident[0] != "Cluster" {
+		return errors.Errorf("%q is not a field of .Cluster", node.String())
+	}
+	return nil
+}
+
+// limitedBuilder is a strings.Builder that fails writes exceeding
+// the given limit in total.
+type limitedBuilder struct {
+	strings.Builder
+	limit int
+}
+
+func (b *limitedBuilder) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errors.Errorf("expanded value exceeds %d bytes", b.limit)
+	}
+	return b.Builder.Write(p)
+}
+
+func isTemplatedValue(value interface{}) bool {
+	switch typedValue := value.(type) {
+	case string:
+		return strings.Contains(typedValue, templateDelimiter)
+	case map[string]interface{}:
+		for _, element := range typedValue {
+			if isTemplatedValue(element) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, element := range typedValue {
+			if isTemplatedValue(element) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestExpandOverrides(t *testing.T) {
+	cluster := &fedv1b1.KubeFedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster1",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"owner": "team-a"},
+		},
+		Status: fedv1b1.KubeFedClusterStatus{
+			Region: ptr.To("us-east1"),
+			Zones:  []string{"us-east1-a", "us-east1-b"},
+		},
+	}
+
+	testCases := map[string]struct {
+		value       interface{}
+		literal     bool
+		expected    interface{}
+		expectedErr bool
+	}{
+		"name": {
+			value:    "{{ .Cluster.Name }}-ingress.example.com",
+			expected: "cluster1-ingress.example.com",
+		},
+		"label and annotation": {
+			value:    "{{ .Cluster.Labels.env }}/{{ .Cluster.Annotations.owner }}",
+			expected: "prod/team-a",
+		},
+		"missing label is empty": {
+			value:    "x{{ .Cluster.Labels.missing }}",
+			expected: "x",
+		},
+		"region and zones": {
+			value:    "{{ .Cluster.Status.Region }}:{{ index .Cluster.Status.Zones 0 }}",
+			expected: "us-east1:us-east1-a",
+		},
+		"nested values": {
+			value: map[string]interface{}{
+				"host":  "{{ .Cluster.Name }}.example.com",
+				"ports": []interface{}{"{{ .Cluster.Labels.env }}", float64(80)},
+			},
+			expected: map[string]interface{}{
+				"host":  "cluster1.example.com",
+				"ports": []interface{}{"prod", float64(80)},
+			},
+		},
+		"plain values are unchanged": {
+			value:    float64(3),
+			expected: float64(3),
+		},
+		"literal braces are unchanged without template": {
+			value:    "{{ $labels.instance }} is down",
+			literal:  true,
+			expected: "{{ $labels.instance }} is down",
+		},
+		"invalid template": {
+			value:       "{{ .Cluster.Name",
+			expectedErr: true,
+		},
+		"unknown field": {
+			value:       "{{ .Cluster.Secret }}",
+			expectedErr: true,
+		},
+		"label selected with index": {
+			value:    `{{ index .Cluster.Labels "env" }}`,
+			expected: "prod",
+		},
+		"escaped braces": {
+			value:    `{{ "{{" }} .Cluster.Name }}`,
+			expected: "{{ .Cluster.Name }}",
+		},
+		"range is rejected": {
+			value:       "{{ range 5 }}ab{{ end }}",
+			expectedErr: true,
+		},
+		"with is rejected": {
+			value:       "{{ with .Cluster.Name }}{{ . }}{{ end }}",
+			expectedErr: true,
+		},
+		"if is rejected": {
+			value:       "{{ if .Cluster.Name }}a{{ end }}",
+			expectedErr: true,
+		},
+		"define is rejected": {
+			value:       `{{ define "a" }}ab{{ end }}x`,
+			expectedErr: true,
+		},
+		"template is rejected": {
+			value:       `{{ template "override" }}`,
+			expectedErr: true,
+		},
+		"function call is rejected": {
+			value:       `{{ printf "%0999999999d" 1 }}`,
+			expectedErr: true,
+		},
+		"pipeline is rejected": {
+			value:       "{{ .Cluster.Name | print }}",
+			expectedErr: true,
+		},
+		"variable is rejected": {
+			value:       "{{ $name := .Cluster.Name }}",
+			expectedErr: true,
+		},
+		"data outside of cluster is rejected": {
+			value:       "{{ . }}",
+			expectedErr: true,
+		},
+		"expanded value exceeding the maximum length is rejected": {
+			value:       strings.Repeat("{{ .Cluster.Name }}", maxExpandedValueLength/len("cluster1")+1),
+			expectedErr: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			overrides := ClusterOverrides{{Path: "/spec/foo", Value: testCase.value, Template: !testCase.literal}}
+			expanded, err := ExpandOverrides(overrides, cluster)
+			if testCase.expectedErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			if !reflect.DeepEqual(expanded[0].Value, testCase.expected) {
+				t.Fatalf("Expected %#v, got %#v", testCase.expected, expanded[0].Value)
+			}
+			if !reflect.DeepEqual(overrides[0].Value, testCase.value) {
+				t.Fatalf("Expected the given overrides to be unmodified")
+			}
+		})
+	}
+}
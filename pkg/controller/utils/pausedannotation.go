@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+const (
+	// PausedAnnotation If this annotation is present on a federated
+	// resource, changes are not propagated to member clusters while
+	// the propagation status continues to be reported.  On a federated
+	// namespace, propagation of all the federated resources in the
+	// namespace is paused.
+	PausedAnnotation = "kubefed.io/paused"
+	PausedValue      = "true"
+)
+
+// IsPaused checks whether propagation of the given federated resource
+// is paused by the paused annotation.
+func IsPaused(obj *unstructured.Unstructured) bool {
+	if obj == nil {
+		return false
+	}
+	return obj.GetAnnotations()[PausedAnnotation] == PausedValue
+}
+
+// Pause pauses propagation of the given federated resource.
+func Pause(obj *unstructured.Unstructured) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[PausedAnnotation] = PausedValue
+	obj.SetAnnotations(annotations)
+}
+
+// Resume resumes propagation of the given federated resource.
+func Resume(obj *unstructured.Unstructured) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		return
+	}
+	delete(annotations, PausedAnnotation)
+	obj.SetAnnotations(annotations)
+}
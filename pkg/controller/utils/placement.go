@@ -17,10 +17,18 @@ limitations under the License.
 package utils
 
 import (
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
 
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 )
@@ -29,11 +37,83 @@ type GenericClusterReference struct {
 	Name string `json:"name"`
 }
 
+// PlacementOperator determines how the clusters and clusterSelector
+// fields of a placement are combined.
+type PlacementOperator string
+
+const (
+	// PlacementOperatorUnion selects a cluster if it is either listed
+	// in clusters or matched by clusterSelector.
+	PlacementOperatorUnion PlacementOperator = "Union"
+	// PlacementOperatorIntersection selects a cluster only if it is
+	// both listed in clusters and matched by clusterSelector.
+	PlacementOperatorIntersection PlacementOperator = "Intersection"
+)
+
 type GenericPlacementFields struct {
-	Clusters        []GenericClusterReference `json:"clusters,omitempty"`
-	ClusterSelector *metav1.LabelSelector     `json:"clusterSelector,omitempty"`
+	Clusters          []GenericClusterReference `json:"clusters,omitempty"`
+	ClusterSelector   *metav1.LabelSelector     `json:"clusterSelector,omitempty"`
+	ExcludeClusters   []GenericClusterReference `json:"excludeClusters,omitempty"`
+	Operator          PlacementOperator         `json:"operator,omitempty"`
+	Regions           []string                  `json:"regions,omitempty"`
+	Zones             []string                  `json:"zones,omitempty"`
+	SpreadConstraints *GenericSpreadConstraints `json:"spreadConstraints,omitempty"`
+	Tolerations       []corev1.Toleration       `json:"tolerations,omitempty"`
+	Failover          *GenericFailoverPolicy    `json:"failover,omitempty"`
 }
 
+// GenericFailoverPolicy names standby clusters that are used in
+// addition to the selected clusters while too few of the selected
+// clusters are ready.
+type GenericFailoverPolicy struct {
+	// MinReadyClusters is the number of selected clusters that must
+	// be ready for standby clusters not to be used.
+	MinReadyClusters int64 `json:"minReadyClusters"`
+	// Clusters are standby clusters in order of preference.
+	Clusters []GenericClusterReference `json:"clusters,omitempty"`
+	// ClusterSelector matches standby clusters that are used after
+	// the clusters listed by name.
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// RevertGracePeriodSeconds is how long enough selected clusters
+	// must have been ready again before standby clusters are no
+	// longer used.
+	RevertGracePeriodSeconds *int64 `json:"revertGracePeriodSeconds,omitempty"`
+}
+
+// DefaultFailoverRevertGracePeriod is the revert grace period used
+// when a failover policy does not specify one.
+const DefaultFailoverRevertGracePeriod = 5 * time.Minute
+
+// RevertGracePeriod returns the revert grace period of the policy.
+func (p *GenericFailoverPolicy) RevertGracePeriod() time.Duration {
+	if p.RevertGracePeriodSeconds == nil {
+		return DefaultFailoverRevertGracePeriod
+	}
+	return time.Duration(*p.RevertGracePeriodSeconds) * time.Second
+}
+
+// GenericSpreadConstraints bounds the number of clusters a federated
+// resource is placed in and spreads the selected clusters across
+// topology domains.
+type GenericSpreadConstraints struct {
+	// MaxClusters is the maximum number of clusters selected.
+	MaxClusters *int64 `json:"maxClusters,omitempty"`
+	// TopologyKey identifies the topology domain of a cluster.  The
+	// keys "region" and "zone" refer to the topology discovered in
+	// cluster status, and any other key refers to a cluster label.
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// MaxPerTopology is the maximum number of clusters selected in a
+	// single topology domain.
+	MaxPerTopology *int64 `json:"maxPerTopology,omitempty"`
+}
+
+const (
+	// TopologyKeyRegion spreads clusters by their discovered region.
+	TopologyKeyRegion = "region"
+	// TopologyKeyZone spreads clusters by their discovered zone.
+	TopologyKeyZone = "zone"
+)
+
 type GenericPlacementSpec struct {
 	Placement GenericPlacementFields `json:"placement,omitempty"`
 }
@@ -58,13 +138,25 @@ func (p *GenericPlacement) ClusterNames() []string {
 	if p.Spec.Placement.Clusters == nil {
 		return nil
 	}
-	var clusterNames []string
+	// An empty list of clusters must remain distinguishable from
+	// clusters not being provided.
+	clusterNames := []string{}
 	for _, cluster := range p.Spec.Placement.Clusters {
 		clusterNames = append(clusterNames, cluster.Name)
 	}
 	return clusterNames
 }
 
+// ExcludedClusterNames returns the names of clusters that must never
+// be selected for placement.
+func (p *GenericPlacement) ExcludedClusterNames() []string {
+	var clusterNames []string
+	for _, cluster := range p.Spec.Placement.ExcludeClusters {
+		clusterNames = append(clusterNames, cluster.Name)
+	}
+	return clusterNames
+}
+
 func (p *GenericPlacement) ClusterSelector() (labels.Selector, error) {
 	return metav1.LabelSelectorAsSelector(p.Spec.Placement.ClusterSelector)
 }
@@ -90,6 +182,18 @@ func SetClusterNames(obj *unstructured.Unstructured, clusterNames []string) erro
 	return unstructured.SetNestedSlice(obj.Object, clusters, SpecField, PlacementField, ClustersField)
 }
 
+// SetExcludedClusterNames sets the list of clusters that must never
+// be selected for placement of the given federated resource.
+func SetExcludedClusterNames(obj *unstructured.Unstructured, clusterNames []string) error {
+	var clusters []interface{}
+	for _, clusterName := range clusterNames {
+		clusters = append(clusters, map[string]interface{}{
+			NameField: clusterName,
+		})
+	}
+	return unstructured.SetNestedSlice(obj.Object, clusters, SpecField, PlacementField, ExcludeClustersField)
+}
+
 func SetClusterSelector(obj *unstructured.Unstructured, clusterSelector map[string]string) error {
 	return unstructured.SetNestedStringMap(obj.Object, clusterSelector, SpecField, PlacementField, ClusterSelectorField, MatchLabelsField)
 }
@@ -108,8 +212,11 @@ func SetClusterSelector(obj *unstructured.Unstructured, clusterSelector map[stri
 // because the single namespace by definition must exist on member
 // clusters, so namespace placement becomes a mechanism for limiting
 // rather than allowing propagation.
+//
+// Spread constraints of the resource are applied last so that the
+// clusters they select are not further limited by namespace placement.
 func ComputeNamespacedPlacement(resource, namespace *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, limitedScope bool, selectorOnly bool) (selectedClusters sets.Set[string], err error) {
-	resourceClusters, err := ComputePlacement(resource, clusters, selectorOnly)
+	resourceClusters, err := computeUnspreadPlacement(resource, clusters, selectorOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +226,7 @@ func ComputeNamespacedPlacement(resource, namespace *unstructured.Unstructured,
 			// Use the resource placement verbatim if no federated
 			// namespace is present and KubeFed is targeting a
 			// single namespace.
-			return resourceClusters, nil
+			return spreadPlacement(resource, resourceClusters, clusters)
 		}
 		// Resource should not exist in any member clusters.
 		return sets.Set[string]{}, nil
@@ -132,12 +239,22 @@ func ComputeNamespacedPlacement(resource, namespace *unstructured.Unstructured,
 
 	// If both namespace and resource placement exist, the desired
 	// list of clusters is their intersection.
-	return resourceClusters.Intersection(namespaceClusters), nil
+	return spreadPlacement(resource, resourceClusters.Intersection(namespaceClusters), clusters)
 }
 
 // ComputePlacement determines the selected clusters for a federated
 // resource.
 func ComputePlacement(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (selectedClusters sets.Set[string], err error) {
+	selectedNames, err := computeUnspreadPlacement(resource, clusters, selectorOnly)
+	if err != nil {
+		return nil, err
+	}
+	return spreadPlacement(resource, selectedNames, clusters)
+}
+
+// computeUnspreadPlacement determines the selected clusters for a
+// federated resource without applying spread constraints.
+func computeUnspreadPlacement(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (sets.Set[string], error) {
 	selectedNames, err := selectedClusterNames(resource, clusters, selectorOnly)
 	if err != nil {
 		return nil, err
@@ -146,35 +263,348 @@ func ComputePlacement(resource *unstructured.Unstructured, clusters []*fedv1b1.K
 	return clusterNames.Intersection(selectedNames), nil
 }
 
-func selectedClusterNames(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (sets.Set[string], error) {
+// spreadPlacement limits the selected clusters according to the
+// spread constraints of the federated resource, if any.
+//
+// Clusters are grouped by topology domain and selected one domain at
+// a time in round-robin fashion until either the maximum number of
+// clusters is reached or no domain can accept another cluster. Both
+// domains and the clusters within a domain are ordered by a hash of
+// their name and the name of the resource so that the selection is
+// stable for a given resource while different resources are spread
+// differently.
+func spreadPlacement(resource *unstructured.Unstructured, selectedNames sets.Set[string], clusters []*fedv1b1.KubeFedCluster) (sets.Set[string], error) {
 	placement, err := UnmarshalGenericPlacement(resource)
 	if err != nil {
 		return nil, err
 	}
+	constraints := placement.Spec.Placement.SpreadConstraints
+	if constraints == nil {
+		return selectedNames, nil
+	}
 
-	selectedNames := sets.Set[string]{}
-	clusterNames := placement.ClusterNames()
-	// Only use selector if clusters are nil. An empty list of
-	// clusters implies no clusters are selected.
-	if selectorOnly || clusterNames == nil {
-		selector, err := placement.ClusterSelector()
-		if err != nil {
-			return nil, err
+	resourceKey := NewQualifiedName(resource).String()
+	domainClusters := make(map[string][]*hashedName)
+	var domains []*hashedName
+	for _, cluster := range clusters {
+		if !selectedNames.Has(cluster.Name) {
+			continue
+		}
+		domain := topologyDomain(cluster, constraints.TopologyKey)
+		if _, ok := domainClusters[domain]; !ok {
+			domains = append(domains, newHashedName(domain, resourceKey))
 		}
-		for _, cluster := range clusters {
-			if selector.Matches(labels.Set(cluster.Labels)) {
-				selectedNames.Insert(cluster.Name)
+		domainClusters[domain] = append(domainClusters[domain], newHashedName(cluster.Name, resourceKey))
+	}
+	sortHashedNames(domains)
+	for _, names := range domainClusters {
+		sortHashedNames(names)
+	}
+
+	spreadNames := sets.Set[string]{}
+	for round := 0; ; round++ {
+		if constraints.MaxPerTopology != nil && int64(round) >= *constraints.MaxPerTopology {
+			break
+		}
+		selected := false
+		for _, domain := range domains {
+			names := domainClusters[domain.name]
+			if round >= len(names) {
+				continue
+			}
+			if constraints.MaxClusters != nil && int64(spreadNames.Len()) >= *constraints.MaxClusters {
+				return spreadNames, nil
 			}
+			spreadNames.Insert(names[round].name)
+			selected = true
 		}
-	} else {
-		for _, clusterName := range clusterNames {
-			selectedNames.Insert(clusterName)
+		if !selected {
+			break
+		}
+	}
+	return spreadNames, nil
+}
+
+// RetainPlacement adds the retainable clusters to the selected
+// clusters of a federated resource for as long as its spread
+// constraints allow.  Selected clusters always count against the
+// constraints first so that retaining a resource never displaces a
+// cluster selected for placement.
+func RetainPlacement(resource *unstructured.Unstructured, selectedNames, retainableNames sets.Set[string], clusters []*fedv1b1.KubeFedCluster) (sets.Set[string], error) {
+	placement, err := UnmarshalGenericPlacement(resource)
+	if err != nil {
+		return nil, err
+	}
+	constraints := placement.Spec.Placement.SpreadConstraints
+	if constraints == nil {
+		return selectedNames.Union(retainableNames), nil
+	}
+
+	resourceKey := NewQualifiedName(resource).String()
+	retainedNames := selectedNames.Clone()
+	domainCounts := make(map[string]int64)
+	var candidates []*hashedName
+	candidateDomains := make(map[string]string)
+	for _, cluster := range clusters {
+		domain := topologyDomain(cluster, constraints.TopologyKey)
+		switch {
+		case selectedNames.Has(cluster.Name):
+			domainCounts[domain]++
+		case retainableNames.Has(cluster.Name):
+			candidates = append(candidates, newHashedName(cluster.Name, resourceKey))
+			candidateDomains[cluster.Name] = domain
+		}
+	}
+	sortHashedNames(candidates)
+
+	for _, candidate := range candidates {
+		if constraints.MaxClusters != nil && int64(retainedNames.Len()) >= *constraints.MaxClusters {
+			break
+		}
+		domain := candidateDomains[candidate.name]
+		if constraints.MaxPerTopology != nil && domainCounts[domain] >= *constraints.MaxPerTopology {
+			continue
+		}
+		retainedNames.Insert(candidate.name)
+		domainCounts[domain]++
+	}
+	return retainedNames, nil
+}
+
+// topologyDomain returns the topology domain of the cluster for the
+// given key.  A cluster with nodes in more than one zone is
+// considered to be in the first of its zones.
+func topologyDomain(cluster *fedv1b1.KubeFedCluster, topologyKey string) string {
+	switch topologyKey {
+	case "":
+		return ""
+	case TopologyKeyRegion:
+		if cluster.Status.Region == nil {
+			return ""
+		}
+		return *cluster.Status.Region
+	case TopologyKeyZone:
+		if len(cluster.Status.Zones) == 0 {
+			return ""
+		}
+		return cluster.Status.Zones[0]
+	default:
+		return cluster.Labels[topologyKey]
+	}
+}
+
+type hashedName struct {
+	name string
+	hash uint32
+}
+
+func newHashedName(name, key string) *hashedName {
+	// Same hashing as the replica planner to avoid always favoring
+	// the alphabetically smallest names.
+	hasher := fnv.New32()
+	_, _ = hasher.Write([]byte(name))
+	_, _ = hasher.Write([]byte(key))
+	return &hashedName{name: name, hash: hasher.Sum32()}
+}
+
+func sortHashedNames(names []*hashedName) {
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].hash < names[j].hash || (names[i].hash == names[j].hash && names[i].name < names[j].name)
+	})
+}
+
+func selectedClusterNames(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (sets.Set[string], error) {
+	placement, err := UnmarshalGenericPlacement(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedNames, err := placement.selectClusterNames(clusters, selectorOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	// Excluded clusters are removed regardless of how the clusters
+	// and selector were combined.
+	selectedNames.Delete(placement.ExcludedClusterNames()...)
+
+	for _, cluster := range clusters {
+		if !selectedNames.Has(cluster.Name) {
+			continue
+		}
+		if !placement.matchesTopology(cluster) || !placement.toleratesTaints(cluster) {
+			selectedNames.Delete(cluster.Name)
 		}
 	}
 
 	return selectedNames, nil
 }
 
+// toleratesTaints returns whether every taint of the cluster is
+// tolerated by the placement.
+func (p *GenericPlacement) toleratesTaints(cluster *fedv1b1.KubeFedCluster) bool {
+	for i := range cluster.Spec.Taints {
+		taint := &cluster.Spec.Taints[i]
+		tolerated := false
+		for j := range p.Spec.Placement.Tolerations {
+			if p.Spec.Placement.Tolerations[j].ToleratesTaint(klog.Background(), taint, false) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// GetFailoverPolicy returns the failover policy of the placement of
+// the given federated resource, or nil if none is specified.
+func GetFailoverPolicy(resource *unstructured.Unstructured) (*GenericFailoverPolicy, error) {
+	placement, err := UnmarshalGenericPlacement(resource)
+	if err != nil {
+		return nil, err
+	}
+	return placement.Spec.Placement.Failover, nil
+}
+
+// ComputeStandbyPlacement determines the standby clusters named by
+// the failover policy of a federated resource in order of
+// preference: clusters listed by name first, followed by clusters
+// matched by the selector in order of name.  Excluded clusters and
+// clusters with taints the placement does not tolerate are never
+// used as standby clusters.
+func ComputeStandbyPlacement(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster) ([]string, error) {
+	placement, err := UnmarshalGenericPlacement(resource)
+	if err != nil {
+		return nil, err
+	}
+	policy := placement.Spec.Placement.Failover
+	if policy == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.ClusterSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	eligibleClusters := make(map[string]*fedv1b1.KubeFedCluster)
+	excludedNames := sets.New[string](placement.ExcludedClusterNames()...)
+	for _, cluster := range clusters {
+		if !excludedNames.Has(cluster.Name) && placement.toleratesTaints(cluster) {
+			eligibleClusters[cluster.Name] = cluster
+		}
+	}
+
+	var standbyNames []string
+	seen := sets.Set[string]{}
+	for _, clusterRef := range policy.Clusters {
+		if _, ok := eligibleClusters[clusterRef.Name]; ok && !seen.Has(clusterRef.Name) {
+			standbyNames = append(standbyNames, clusterRef.Name)
+			seen.Insert(clusterRef.Name)
+		}
+	}
+	var matchedNames []string
+	for name, cluster := range eligibleClusters {
+		if !seen.Has(name) && selector.Matches(labels.Set(cluster.Labels)) {
+			matchedNames = append(matchedNames, name)
+		}
+	}
+	sort.Strings(matchedNames)
+	return append(standbyNames, matchedNames...), nil
+}
+
+// WithoutNoScheduleTaints returns the given clusters with NoSchedule
+// taints removed.  Computing placement against the result yields the
+// clusters in which existing resources may remain, since NoSchedule
+// taints only prevent new placement.
+func WithoutNoScheduleTaints(clusters []*fedv1b1.KubeFedCluster) []*fedv1b1.KubeFedCluster {
+	result := make([]*fedv1b1.KubeFedCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		var taints []corev1.Taint
+		hasNoSchedule := false
+		for _, taint := range cluster.Spec.Taints {
+			if taint.Effect == corev1.TaintEffectNoSchedule {
+				hasNoSchedule = true
+				continue
+			}
+			taints = append(taints, taint)
+		}
+		if !hasNoSchedule {
+			result = append(result, cluster)
+			continue
+		}
+		untainted := cluster.DeepCopy()
+		untainted.Spec.Taints = taints
+		result = append(result, untainted)
+	}
+	return result
+}
+
+// matchesTopology returns whether the region and zones discovered
+// for the cluster satisfy the regions and zones of the placement.  A
+// cluster matches the zones of the placement if any of its zones is
+// listed.  Regions or zones that are not provided do not constrain
+// placement.
+func (p *GenericPlacement) matchesTopology(cluster *fedv1b1.KubeFedCluster) bool {
+	if regions := p.Spec.Placement.Regions; len(regions) > 0 {
+		if cluster.Status.Region == nil || !sets.New[string](regions...).Has(*cluster.Status.Region) {
+			return false
+		}
+	}
+	if zones := p.Spec.Placement.Zones; len(zones) > 0 {
+		if !sets.New[string](zones...).HasAny(cluster.Status.Zones...) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectClusterNames combines the clusters and clusterSelector fields
+// of the placement according to its operator.  If no operator is
+// specified, the selector is only used if clusters are nil. An empty
+// list of clusters implies no clusters are selected.
+func (p *GenericPlacement) selectClusterNames(clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (sets.Set[string], error) {
+	clusterNames := p.ClusterNames()
+	operator := p.Spec.Placement.Operator
+
+	if !selectorOnly && operator == "" && clusterNames != nil {
+		return sets.New[string](clusterNames...), nil
+	}
+
+	selector, err := p.ClusterSelector()
+	if err != nil {
+		return nil, err
+	}
+	matchedNames := sets.Set[string]{}
+	for _, cluster := range clusters {
+		if selector.Matches(labels.Set(cluster.Labels)) {
+			matchedNames.Insert(cluster.Name)
+		}
+	}
+	if selectorOnly || operator == "" {
+		return matchedNames, nil
+	}
+
+	switch operator {
+	case PlacementOperatorUnion:
+		return matchedNames.Insert(clusterNames...), nil
+	case PlacementOperatorIntersection:
+		// A field that is not provided does not constrain the
+		// intersection.
+		if clusterNames == nil {
+			return matchedNames, nil
+		}
+		if p.Spec.Placement.ClusterSelector == nil {
+			return sets.New[string](clusterNames...), nil
+		}
+		return matchedNames.Intersection(sets.New[string](clusterNames...)), nil
+	default:
+		return nil, errors.Errorf("unsupported placement operator %q", operator)
+	}
+}
+
 func getClusterNames(clusters []*fedv1b1.KubeFedCluster) sets.Set[string] {
 	clusterNames := sets.Set[string]{}
 	for _, cluster := range clusters {
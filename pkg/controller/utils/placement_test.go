@@ -20,9 +20,11 @@ import (
 	"reflect"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 )
@@ -41,38 +43,143 @@ func TestSelectedClusterNames(t *testing.T) {
 					"foo": "bar",
 				},
 			},
+			Status: fedv1b1.KubeFedClusterStatus{
+				Region: ptr.To("us-east1"),
+				Zones:  []string{"us-east1-a", "us-east1-b"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster3",
+				Labels: map[string]string{
+					"foo": "bar",
+				},
+			},
+			Status: fedv1b1.KubeFedClusterStatus{
+				Region: ptr.To("eu-west1"),
+				Zones:  []string{"eu-west1-b"},
+			},
 		},
 	}
 
 	testCases := map[string]struct {
 		clusterNames    []string
 		clusterSelector map[string]string
-		expectedNames   sets.String
+		excludeClusters []string
+		operator        PlacementOperator
+		regions         []string
+		zones           []string
+		expectedNames   sets.Set[string]
+		expectedErr     bool
 	}{
 		"ignore cluster selector when cluster names present": {
 			clusterNames:    []string{"cluster1"},
 			clusterSelector: map[string]string{},
-			expectedNames:   sets.NewString("cluster1"),
+			expectedNames:   sets.New[string]("cluster1"),
 		},
 		"no clusters when cluster names and selector absent": {
-			expectedNames: sets.NewString(),
+			expectedNames: sets.New[string](),
 		},
 		"no clusters when cluster names empty and selector not empty": {
 			clusterNames: []string{},
 			clusterSelector: map[string]string{
 				"foo": "bar",
 			},
-			expectedNames: sets.NewString(),
+			expectedNames: sets.New[string](),
 		},
 		"all clusters when cluster names absent and selector empty": {
 			clusterSelector: map[string]string{},
-			expectedNames:   sets.NewString("cluster1", "cluster2"),
+			expectedNames:   sets.New[string]("cluster1", "cluster2", "cluster3"),
 		},
 		"selected clusters when cluster names absent and selector not empty": {
 			clusterSelector: map[string]string{
 				"foo": "bar",
 			},
-			expectedNames: sets.NewString("cluster2"),
+			expectedNames: sets.New[string]("cluster2", "cluster3"),
+		},
+		"excluded clusters removed from selected clusters": {
+			clusterSelector: map[string]string{
+				"foo": "bar",
+			},
+			excludeClusters: []string{"cluster3"},
+			expectedNames:   sets.New[string]("cluster2"),
+		},
+		"excluded clusters removed from cluster names": {
+			clusterNames:    []string{"cluster1", "cluster2"},
+			excludeClusters: []string{"cluster1"},
+			expectedNames:   sets.New[string]("cluster2"),
+		},
+		"union of cluster names and selector": {
+			clusterNames: []string{"cluster1"},
+			clusterSelector: map[string]string{
+				"foo": "bar",
+			},
+			operator:      PlacementOperatorUnion,
+			expectedNames: sets.New[string]("cluster1", "cluster2", "cluster3"),
+		},
+		"union of cluster names and selector with excluded clusters": {
+			clusterNames: []string{"cluster1"},
+			clusterSelector: map[string]string{
+				"foo": "bar",
+			},
+			excludeClusters: []string{"cluster2"},
+			operator:        PlacementOperatorUnion,
+			expectedNames:   sets.New[string]("cluster1", "cluster3"),
+		},
+		"union with empty cluster names uses selector": {
+			clusterNames: []string{},
+			clusterSelector: map[string]string{
+				"foo": "bar",
+			},
+			operator:      PlacementOperatorUnion,
+			expectedNames: sets.New[string]("cluster2", "cluster3"),
+		},
+		"intersection of cluster names and selector": {
+			clusterNames: []string{"cluster1", "cluster2"},
+			clusterSelector: map[string]string{
+				"foo": "bar",
+			},
+			operator:      PlacementOperatorIntersection,
+			expectedNames: sets.New[string]("cluster2"),
+		},
+		"intersection with cluster names absent uses selector": {
+			clusterSelector: map[string]string{
+				"foo": "bar",
+			},
+			excludeClusters: []string{"cluster2"},
+			operator:        PlacementOperatorIntersection,
+			expectedNames:   sets.New[string]("cluster3"),
+		},
+		"intersection with selector absent uses cluster names": {
+			clusterNames:  []string{"cluster1", "cluster2"},
+			operator:      PlacementOperatorIntersection,
+			expectedNames: sets.New[string]("cluster1", "cluster2"),
+		},
+		"clusters restricted to regions": {
+			clusterSelector: map[string]string{},
+			regions:         []string{"eu-west1"},
+			expectedNames:   sets.New[string]("cluster3"),
+		},
+		"clusters without a discovered region not selected by regions": {
+			clusterNames:  []string{"cluster1", "cluster2"},
+			regions:       []string{"us-east1", "eu-west1"},
+			expectedNames: sets.New[string]("cluster2"),
+		},
+		"clusters restricted to zones": {
+			clusterSelector: map[string]string{},
+			zones:           []string{"us-east1-b", "asia-east1-a"},
+			expectedNames:   sets.New[string]("cluster2"),
+		},
+		"clusters restricted to both regions and zones": {
+			clusterSelector: map[string]string{},
+			regions:         []string{"us-east1", "eu-west1"},
+			zones:           []string{"eu-west1-b"},
+			expectedNames:   sets.New[string]("cluster3"),
+		},
+		"error on unsupported operator": {
+			clusterNames: []string{"cluster1"},
+			operator:     PlacementOperator("Difference"),
+			expectedErr:  true,
 		},
 	}
 
@@ -91,14 +198,468 @@ func TestSelectedClusterNames(t *testing.T) {
 					t.Fatalf("Unexpected error: %v", err)
 				}
 			}
+			if testCase.excludeClusters != nil {
+				if err := SetExcludedClusterNames(obj, testCase.excludeClusters); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			if testCase.regions != nil {
+				if err := unstructured.SetNestedStringSlice(obj.Object, testCase.regions, SpecField, PlacementField, RegionsField); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			if testCase.zones != nil {
+				if err := unstructured.SetNestedStringSlice(obj.Object, testCase.zones, SpecField, PlacementField, ZonesField); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			if testCase.operator != "" {
+				if err := unstructured.SetNestedField(obj.Object, string(testCase.operator), SpecField, PlacementField, OperatorField); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
 
 			selectedNames, err := selectedClusterNames(obj, clusters, false)
+			if testCase.expectedErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(selectedNames, testCase.expectedNames) {
+				t.Fatalf("Expected names %v, got %v", testCase.expectedNames, selectedNames)
+			}
+		})
+	}
+}
+
+func TestSpreadPlacement(t *testing.T) {
+	newCluster := func(name, region, rack string) *fedv1b1.KubeFedCluster {
+		cluster := &fedv1b1.KubeFedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"rack": rack},
+			},
+		}
+		if region != "" {
+			cluster.Status.Region = ptr.To(region)
+			cluster.Status.Zones = []string{region + "-a"}
+		}
+		return cluster
+	}
+	clusters := []*fedv1b1.KubeFedCluster{
+		newCluster("cluster1", "region1", "rack1"),
+		newCluster("cluster2", "region1", "rack1"),
+		newCluster("cluster3", "region1", "rack2"),
+		newCluster("cluster4", "region2", "rack2"),
+		newCluster("cluster5", "region3", "rack3"),
+		newCluster("cluster6", "", "rack3"),
+	}
+	domainOf := func(name, topologyKey string) string {
+		for _, cluster := range clusters {
+			if cluster.Name == name {
+				return topologyDomain(cluster, topologyKey)
+			}
+		}
+		t.Fatalf("Unknown cluster %q", name)
+		return ""
+	}
+
+	testCases := map[string]struct {
+		constraints         map[string]interface{}
+		expectedCount       int
+		expectedPerTopology int
+		topologyKey         string
+	}{
+		"all clusters when no constraints are set": {
+			expectedCount: 6,
+		},
+		"bounded number of clusters without topology": {
+			constraints: map[string]interface{}{
+				"maxClusters": int64(3),
+			},
+			expectedCount: 3,
+		},
+		"one cluster per region": {
+			constraints: map[string]interface{}{
+				"topologyKey":    TopologyKeyRegion,
+				"maxPerTopology": int64(1),
+			},
+			topologyKey:         TopologyKeyRegion,
+			expectedCount:       4,
+			expectedPerTopology: 1,
+		},
+		"bounded number of clusters spread across regions": {
+			constraints: map[string]interface{}{
+				"maxClusters":    int64(3),
+				"topologyKey":    TopologyKeyRegion,
+				"maxPerTopology": int64(1),
+			},
+			topologyKey:         TopologyKeyRegion,
+			expectedCount:       3,
+			expectedPerTopology: 1,
+		},
+		"clusters spread across regions before a region is reused": {
+			constraints: map[string]interface{}{
+				"maxClusters": int64(5),
+				"topologyKey": TopologyKeyRegion,
+			},
+			topologyKey:         TopologyKeyRegion,
+			expectedCount:       5,
+			expectedPerTopology: 2,
+		},
+		"at most two clusters per zone": {
+			constraints: map[string]interface{}{
+				"topologyKey":    TopologyKeyZone,
+				"maxPerTopology": int64(2),
+			},
+			topologyKey:         TopologyKeyZone,
+			expectedCount:       5,
+			expectedPerTopology: 2,
+		},
+		"one cluster per label value": {
+			constraints: map[string]interface{}{
+				"topologyKey":    "rack",
+				"maxPerTopology": int64(1),
+			},
+			topologyKey:         "rack",
+			expectedCount:       3,
+			expectedPerTopology: 1,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":      "foo",
+						"namespace": "bar",
+					},
+					"spec": make(map[string]interface{}),
+				},
+			}
+			if err := SetClusterSelector(obj, map[string]string{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if testCase.constraints != nil {
+				if err := unstructured.SetNestedField(obj.Object, testCase.constraints, SpecField, PlacementField, SpreadConstraintsField); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			selectedNames, err := ComputePlacement(obj, clusters, false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if selectedNames.Len() != testCase.expectedCount {
+				t.Fatalf("Expected %d clusters, got %v", testCase.expectedCount, sets.List(selectedNames))
+			}
+			if testCase.expectedPerTopology > 0 {
+				perTopology := make(map[string]int)
+				for _, name := range sets.List(selectedNames) {
+					domain := domainOf(name, testCase.topologyKey)
+					perTopology[domain]++
+					if perTopology[domain] > testCase.expectedPerTopology {
+						t.Fatalf("Expected at most %d clusters per topology domain, got %v", testCase.expectedPerTopology, sets.List(selectedNames))
+					}
+				}
+			}
+
+			// Selection must be deterministic.
+			for i := 0; i < 5; i++ {
+				repeatedNames, err := ComputePlacement(obj, clusters, false)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if !selectedNames.Equal(repeatedNames) {
+					t.Fatalf("Expected stable selection %v, got %v", sets.List(selectedNames), sets.List(repeatedNames))
+				}
+			}
+		})
+	}
+}
+
+func TestTaintTolerations(t *testing.T) {
+	newCluster := func(name string, taints ...corev1.Taint) *fedv1b1.KubeFedCluster {
+		return &fedv1b1.KubeFedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: fedv1b1.KubeFedClusterSpec{
+				Taints: taints,
+			},
+		}
+	}
+	noSchedule := corev1.Taint{Key: "maintenance", Value: "true", Effect: corev1.TaintEffectNoSchedule}
+	noExecute := corev1.Taint{Key: "decommissioned", Effect: corev1.TaintEffectNoExecute}
+	clusters := []*fedv1b1.KubeFedCluster{
+		newCluster("cluster1"),
+		newCluster("cluster2", noSchedule),
+		newCluster("cluster3", noExecute),
+		newCluster("cluster4", noSchedule, noExecute),
+	}
+
+	testCases := map[string]struct {
+		tolerations           []interface{}
+		expectedNames         sets.Set[string]
+		expectedRetainedNames sets.Set[string]
+	}{
+		"tainted clusters are skipped": {
+			expectedNames:         sets.New[string]("cluster1"),
+			expectedRetainedNames: sets.New[string]("cluster1", "cluster2"),
+		},
+		"matching key and value are tolerated": {
+			tolerations: []interface{}{
+				map[string]interface{}{
+					"key":      "maintenance",
+					"operator": "Equal",
+					"value":    "true",
+					"effect":   "NoSchedule",
+				},
+			},
+			expectedNames:         sets.New[string]("cluster1", "cluster2"),
+			expectedRetainedNames: sets.New[string]("cluster1", "cluster2"),
+		},
+		"mismatched value is not tolerated": {
+			tolerations: []interface{}{
+				map[string]interface{}{
+					"key":      "maintenance",
+					"operator": "Equal",
+					"value":    "false",
+				},
+			},
+			expectedNames:         sets.New[string]("cluster1"),
+			expectedRetainedNames: sets.New[string]("cluster1", "cluster2"),
+		},
+		"every taint must be tolerated": {
+			tolerations: []interface{}{
+				map[string]interface{}{
+					"key":      "decommissioned",
+					"operator": "Exists",
+				},
+			},
+			expectedNames:         sets.New[string]("cluster1", "cluster3"),
+			expectedRetainedNames: sets.New[string]("cluster1", "cluster2", "cluster3", "cluster4"),
+		},
+		"empty key with exists tolerates all taints": {
+			tolerations: []interface{}{
+				map[string]interface{}{
+					"operator": "Exists",
+				},
+			},
+			expectedNames:         sets.New[string]("cluster1", "cluster2", "cluster3", "cluster4"),
+			expectedRetainedNames: sets.New[string]("cluster1", "cluster2", "cluster3", "cluster4"),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": make(map[string]interface{}),
+				},
+			}
+			if err := unstructured.SetNestedStringMap(obj.Object, map[string]string{}, SpecField, PlacementField, ClusterSelectorField, MatchLabelsField); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if testCase.tolerations != nil {
+				if err := unstructured.SetNestedSlice(obj.Object, testCase.tolerations, SpecField, PlacementField, TolerationsField); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			selectedNames, err := ComputePlacement(obj, clusters, false)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
 			if !reflect.DeepEqual(selectedNames, testCase.expectedNames) {
 				t.Fatalf("Expected names %v, got %v", testCase.expectedNames, selectedNames)
 			}
+
+			retainedNames, err := ComputePlacement(obj, WithoutNoScheduleTaints(clusters), false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(retainedNames, testCase.expectedRetainedNames) {
+				t.Fatalf("Expected retained names %v, got %v", testCase.expectedRetainedNames, retainedNames)
+			}
+		})
+	}
+
+	if len(clusters[1].Spec.Taints) != 1 {
+		t.Fatalf("Expected the taints of the original cluster to be unchanged")
+	}
+}
+
+func TestRetainPlacement(t *testing.T) {
+	newCluster := func(name, region string) *fedv1b1.KubeFedCluster {
+		return &fedv1b1.KubeFedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Status: fedv1b1.KubeFedClusterStatus{
+				Region: ptr.To(region),
+			},
+		}
+	}
+	clusters := []*fedv1b1.KubeFedCluster{
+		newCluster("cluster1", "region1"),
+		newCluster("cluster2", "region1"),
+		newCluster("cluster3", "region2"),
+		newCluster("cluster4", "region2"),
+	}
+
+	testCases := map[string]struct {
+		constraints   map[string]interface{}
+		retainable    sets.Set[string]
+		expectedNames sets.Set[string]
+	}{
+		"all retainable clusters without constraints": {
+			retainable:    sets.New[string]("cluster3", "cluster4"),
+			expectedNames: sets.New[string]("cluster1", "cluster3", "cluster4"),
+		},
+		"no retainable clusters beyond the maximum number of clusters": {
+			constraints: map[string]interface{}{
+				"maxClusters": int64(1),
+			},
+			retainable:    sets.New[string]("cluster3", "cluster4"),
+			expectedNames: sets.New[string]("cluster1"),
+		},
+		"retainable clusters up to the maximum number of clusters": {
+			constraints: map[string]interface{}{
+				"maxClusters": int64(2),
+			},
+			retainable:    sets.New[string]("cluster3"),
+			expectedNames: sets.New[string]("cluster1", "cluster3"),
+		},
+		"no retainable clusters beyond the maximum per topology": {
+			constraints: map[string]interface{}{
+				"topologyKey":    TopologyKeyRegion,
+				"maxPerTopology": int64(1),
+			},
+			retainable:    sets.New[string]("cluster2"),
+			expectedNames: sets.New[string]("cluster1"),
+		},
+		"retainable clusters up to the maximum per topology": {
+			constraints: map[string]interface{}{
+				"topologyKey":    TopologyKeyRegion,
+				"maxPerTopology": int64(1),
+			},
+			retainable:    sets.New[string]("cluster2", "cluster3"),
+			expectedNames: sets.New[string]("cluster1", "cluster3"),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":      "foo",
+						"namespace": "bar",
+					},
+					"spec": make(map[string]interface{}),
+				},
+			}
+			if testCase.constraints != nil {
+				if err := unstructured.SetNestedField(obj.Object, testCase.constraints, SpecField, PlacementField, SpreadConstraintsField); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			retainedNames, err := RetainPlacement(obj, sets.New[string]("cluster1"), testCase.retainable, clusters)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !retainedNames.Equal(testCase.expectedNames) {
+				t.Fatalf("Expected names %v, got %v", sets.List(testCase.expectedNames), sets.List(retainedNames))
+			}
+		})
+	}
+}
+
+func TestComputeStandbyPlacement(t *testing.T) {
+	newCluster := func(name string, clusterLabels map[string]string, taints ...corev1.Taint) *fedv1b1.KubeFedCluster {
+		return &fedv1b1.KubeFedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: clusterLabels,
+			},
+			Spec: fedv1b1.KubeFedClusterSpec{
+				Taints: taints,
+			},
+		}
+	}
+	standbyLabels := map[string]string{"role": "standby"}
+	clusters := []*fedv1b1.KubeFedCluster{
+		newCluster("cluster1", nil),
+		newCluster("cluster2", standbyLabels),
+		newCluster("cluster3", standbyLabels),
+		newCluster("cluster4", standbyLabels, corev1.Taint{Key: "maintenance", Effect: corev1.TaintEffectNoSchedule}),
+		newCluster("cluster5", nil),
+	}
+
+	testCases := map[string]struct {
+		failover        map[string]interface{}
+		excludeClusters []string
+		expectedNames   []string
+	}{
+		"no failover policy": {},
+		"listed clusters precede selected clusters": {
+			failover: map[string]interface{}{
+				"minReadyClusters": int64(1),
+				"clusters": []interface{}{
+					map[string]interface{}{"name": "cluster5"},
+					map[string]interface{}{"name": "cluster3"},
+					map[string]interface{}{"name": "missing"},
+				},
+				"clusterSelector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"role": "standby"},
+				},
+			},
+			expectedNames: []string{"cluster5", "cluster3", "cluster2"},
+		},
+		"excluded and tainted clusters are not used": {
+			failover: map[string]interface{}{
+				"minReadyClusters": int64(1),
+				"clusterSelector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"role": "standby"},
+				},
+			},
+			excludeClusters: []string{"cluster2"},
+			expectedNames:   []string{"cluster3"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": make(map[string]interface{}),
+				},
+			}
+			if testCase.failover != nil {
+				if err := unstructured.SetNestedMap(obj.Object, testCase.failover, SpecField, PlacementField, FailoverField); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			if testCase.excludeClusters != nil {
+				if err := SetExcludedClusterNames(obj, testCase.excludeClusters); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			standbyNames, err := ComputeStandbyPlacement(obj, clusters)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(standbyNames, testCase.expectedNames) {
+				t.Fatalf("Expected names %v, got %v", testCase.expectedNames, standbyNames)
+			}
 		})
 	}
 }
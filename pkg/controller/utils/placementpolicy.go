@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fedv1a1 "sigs.k8s.io/kubefed/pkg/apis/core/v1alpha1"
+)
+
+// GetPlacementRef returns the name of the PlacementPolicy referenced
+// by the given federated resource, or an empty string if the resource
+// does not reference one.
+func GetPlacementRef(obj *unstructured.Unstructured) (string, error) {
+	name, _, err := unstructured.NestedString(obj.Object, SpecField, PlacementRefField, NameField)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve placement reference")
+	}
+	return name, nil
+}
+
+// PlacementPolicyName returns the qualified name of the
+// PlacementPolicy referenced by the given federated resource.  A
+// namespaced resource references a policy in its own namespace and a
+// cluster-scoped resource references a policy in the KubeFed system
+// namespace.
+func PlacementPolicyName(obj *unstructured.Unstructured, policyName, fedNamespace string) QualifiedName {
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = fedNamespace
+	}
+	return QualifiedName{Namespace: namespace, Name: policyName}
+}
+
+// ApplyPlacementPolicy returns a copy of the given federated resource
+// whose placement is replaced by the placement of the given policy.
+func ApplyPlacementPolicy(obj *unstructured.Unstructured, policy *fedv1a1.PlacementPolicy) (*unstructured.Unstructured, error) {
+	placement, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&policy.Spec.Placement)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert placement of PlacementPolicy %q", NewQualifiedName(policy))
+	}
+	result := obj.DeepCopy()
+	err = unstructured.SetNestedField(result.Object, placement, SpecField, PlacementField)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set placement")
+	}
+	return result, nil
+}
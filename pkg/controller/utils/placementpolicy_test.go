@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	fedv1a1 "sigs.k8s.io/kubefed/pkg/apis/core/v1alpha1"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestApplyPlacementPolicy(t *testing.T) {
+	clusters := []*fedv1b1.KubeFedCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster1",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster2",
+				Labels: map[string]string{
+					"foo": "bar",
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		placement     fedv1a1.Placement
+		expectedNames sets.Set[string]
+	}{
+		"policy clusters replace resource placement": {
+			placement: fedv1a1.Placement{
+				Clusters: []fedv1a1.ClusterReference{{Name: "cluster2"}},
+			},
+			expectedNames: sets.New[string]("cluster2"),
+		},
+		"policy selector is used when clusters are not provided": {
+			placement: fedv1a1.Placement{
+				ClusterSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"foo": "bar"},
+				},
+			},
+			expectedNames: sets.New[string]("cluster2"),
+		},
+		"empty policy clusters select no clusters": {
+			placement: fedv1a1.Placement{
+				Clusters:        []fedv1a1.ClusterReference{},
+				ClusterSelector: &metav1.LabelSelector{},
+			},
+			expectedNames: sets.New[string](),
+		},
+		"policy exclusions apply": {
+			placement: fedv1a1.Placement{
+				ClusterSelector: &metav1.LabelSelector{},
+				ExcludeClusters: []fedv1a1.ClusterReference{{Name: "cluster1"}},
+			},
+			expectedNames: sets.New[string]("cluster2"),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": make(map[string]interface{}),
+				},
+			}
+			if err := SetClusterNames(obj, []string{"cluster1"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			policy := &fedv1a1.PlacementPolicy{
+				Spec: fedv1a1.PlacementPolicySpec{
+					Placement: testCase.placement,
+				},
+			}
+
+			resolved, err := ApplyPlacementPolicy(obj, policy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			selectedNames, err := ComputePlacement(resolved, clusters, false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(selectedNames, testCase.expectedNames) {
+				t.Fatalf("Expected names %v, got %v", testCase.expectedNames, selectedNames)
+			}
+			if clusterNames, _ := GetClusterNames(obj); !reflect.DeepEqual(clusterNames, []string{"cluster1"}) {
+				t.Fatalf("Expected the original resource to be unchanged, got clusters %v", clusterNames)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"sync"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/metrics"
+)
+
+// WorkPriority is the priority with which a queued resource is
+// reconciled.  Resources of a higher priority are always reconciled
+// before resources of a lower priority.
+type WorkPriority int
+
+const (
+	PriorityHigh WorkPriority = iota
+	PriorityMedium
+	PriorityLow
+
+	numPriorities = 3
+)
+
+func (p WorkPriority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityMedium:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// WorkClass identifies why a resource was enqueued.
+type WorkClass int
+
+const (
+	// UserChangeWork reconciles a resource after it was changed.
+	UserChangeWork WorkClass = iota
+	// ClusterSyncWork reconciles a resource after a change to the
+	// member clusters or while their caches are not synced.
+	ClusterSyncWork
+	// RetryWork reconciles a resource again after a delay or an
+	// error.
+	RetryWork
+)
+
+// WorkPriorities assigns a priority to each class of work.
+type WorkPriorities struct {
+	UserChange  WorkPriority
+	ClusterSync WorkPriority
+	Retry       WorkPriority
+}
+
+// DefaultWorkPriorities reconciles changes ahead of cluster syncs ahead
+// of retries.
+var DefaultWorkPriorities = WorkPriorities{
+	UserChange:  PriorityHigh,
+	ClusterSync: PriorityMedium,
+	Retry:       PriorityLow,
+}
+
+// NewWorkPriorities returns the default priorities with those
+// configured by the given spec applied.
+func NewWorkPriorities(spec *fedv1b1.SyncPriorities) WorkPriorities {
+	priorities := DefaultWorkPriorities
+	if spec == nil {
+		return priorities
+	}
+	if spec.UserChange != nil {
+		priorities.UserChange = workPriority(*spec.UserChange)
+	}
+	if spec.ClusterSync != nil {
+		priorities.ClusterSync = workPriority(*spec.ClusterSync)
+	}
+	if spec.Retry != nil {
+		priorities.Retry = workPriority(*spec.Retry)
+	}
+	return priorities
+}
+
+func workPriority(priority fedv1b1.SyncPriority) WorkPriority {
+	switch priority {
+	case fedv1b1.SyncPriorityHigh:
+		return PriorityHigh
+	case fedv1b1.SyncPriorityMedium:
+		return PriorityMedium
+	default:
+		return PriorityLow
+	}
+}
+
+// Priority returns the priority of the given class of work.
+func (p WorkPriorities) Priority(class WorkClass) WorkPriority {
+	switch class {
+	case UserChangeWork:
+		return p.UserChange
+	case ClusterSyncWork:
+		return p.ClusterSync
+	default:
+		return p.Retry
+	}
+}
+
+// priorityQueue is a work queue that hands out the queued resource of
+// the highest priority first, and resources of the same priority in
+// the order they were added.  Like workqueue.Interface, a resource is
+// queued at most once, and a resource added while it is processed is
+// queued again once processing is done.
+type priorityQueue struct {
+	name string
+	cond *sync.Cond
+
+	// queues holds the resources waiting to be processed by priority.
+	queues [numPriorities][]QualifiedName
+	// dirty holds the priority of each resource waiting to be
+	// processed, including resources added while being processed.
+	dirty map[QualifiedName]WorkPriority
+	// processing holds the resources being processed.
+	processing map[QualifiedName]bool
+
+	shuttingDown bool
+}
+
+func newPriorityQueue(name string) *priorityQueue {
+	q := &priorityQueue{
+		name:       name,
+		cond:       sync.NewCond(&sync.Mutex{}),
+		dirty:      make(map[QualifiedName]WorkPriority),
+		processing: make(map[QualifiedName]bool),
+	}
+	for i := 0; i < numPriorities; i++ {
+		metrics.UpdateWorkQueueDepth(name, WorkPriority(i).String(), 0)
+	}
+	return q
+}
+
+// Add queues the given resource.  A resource that is already queued
+// keeps its place unless the given priority is higher.
+func (q *priorityQueue) Add(qualifiedName QualifiedName, priority WorkPriority) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+
+	previous, queued := q.dirty[qualifiedName]
+	if queued && previous <= priority {
+		return
+	}
+	q.dirty[qualifiedName] = priority
+	if q.processing[qualifiedName] {
+		return
+	}
+	if queued {
+		q.remove(qualifiedName, previous)
+	}
+	q.push(qualifiedName, priority)
+	q.cond.Signal()
+}
+
+// Get blocks until a resource can be processed and returns the resource
+// of the highest priority.  quit is true once the queue is shut down.
+func (q *priorityQueue) Get() (qualifiedName QualifiedName, quit bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for q.len() == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if q.len() == 0 {
+		return QualifiedName{}, true
+	}
+
+	for i := range q.queues {
+		if len(q.queues[i]) == 0 {
+			continue
+		}
+		qualifiedName = q.queues[i][0]
+		q.queues[i] = q.queues[i][1:]
+		metrics.UpdateWorkQueueDepth(q.name, WorkPriority(i).String(), len(q.queues[i]))
+		break
+	}
+	delete(q.dirty, qualifiedName)
+	q.processing[qualifiedName] = true
+	return qualifiedName, false
+}
+
+// Done marks the processing of the given resource as done.  The
+// resource is queued again if it was added while being processed.
+func (q *priorityQueue) Done(qualifiedName QualifiedName) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	delete(q.processing, qualifiedName)
+	if priority, ok := q.dirty[qualifiedName]; ok {
+		q.push(qualifiedName, priority)
+		q.cond.Signal()
+	}
+}
+
+// ShutDown causes Get to return quit once the queue is drained.
+func (q *priorityQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+// Len returns the number of resources queued with the given priority.
+func (q *priorityQueue) Len(priority WorkPriority) int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.queues[priority])
+}
+
+func (q *priorityQueue) len() int {
+	total := 0
+	for i := range q.queues {
+		total += len(q.queues[i])
+	}
+	return total
+}
+
+func (q *priorityQueue) push(qualifiedName QualifiedName, priority WorkPriority) {
+	q.queues[priority] = append(q.queues[priority], qualifiedName)
+	metrics.UpdateWorkQueueDepth(q.name, priority.String(), len(q.queues[priority]))
+}
+
+func (q *priorityQueue) remove(qualifiedName QualifiedName, priority WorkPriority) {
+	queue := q.queues[priority]
+	for i := range queue {
+		if queue[i] == qualifiedName {
+			q.queues[priority] = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+	metrics.UpdateWorkQueueDepth(q.name, priority.String(), len(q.queues[priority]))
+}
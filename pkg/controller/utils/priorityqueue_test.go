@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	a := QualifiedName{Namespace: "ns", Name: "a"}
+	b := QualifiedName{Namespace: "ns", Name: "b"}
+	c := QualifiedName{Namespace: "ns", Name: "c"}
+
+	type add struct {
+		qualifiedName QualifiedName
+		priority      WorkPriority
+	}
+	testCases := map[string]struct {
+		adds     []add
+		expected []QualifiedName
+	}{
+		"higher priority first": {
+			adds: []add{
+				{a, PriorityLow},
+				{b, PriorityMedium},
+				{c, PriorityHigh},
+			},
+			expected: []QualifiedName{c, b, a},
+		},
+		"same priority in order added": {
+			adds: []add{
+				{b, PriorityMedium},
+				{a, PriorityMedium},
+			},
+			expected: []QualifiedName{b, a},
+		},
+		"queued resource is not duplicated": {
+			adds: []add{
+				{a, PriorityHigh},
+				{b, PriorityHigh},
+				{a, PriorityLow},
+			},
+			expected: []QualifiedName{a, b},
+		},
+		"queued resource is raised to a higher priority": {
+			adds: []add{
+				{a, PriorityLow},
+				{b, PriorityMedium},
+				{a, PriorityHigh},
+			},
+			expected: []QualifiedName{a, b},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			q := newPriorityQueue("test")
+			for _, add := range testCase.adds {
+				q.Add(add.qualifiedName, add.priority)
+			}
+			q.ShutDown()
+
+			var result []QualifiedName
+			for {
+				qualifiedName, quit := q.Get()
+				if quit {
+					break
+				}
+				result = append(result, qualifiedName)
+				q.Done(qualifiedName)
+			}
+			if !reflect.DeepEqual(result, testCase.expected) {
+				t.Fatalf("Expected resources in order %v, got %v", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestPriorityQueueAddWhileProcessing(t *testing.T) {
+	a := QualifiedName{Namespace: "ns", Name: "a"}
+	b := QualifiedName{Namespace: "ns", Name: "b"}
+
+	q := newPriorityQueue("test")
+	q.Add(a, PriorityLow)
+	qualifiedName, _ := q.Get()
+	if qualifiedName != a {
+		t.Fatalf("Expected %v, got %v", a, qualifiedName)
+	}
+
+	// A resource added while being processed is only queued once
+	// processing is done.
+	q.Add(a, PriorityHigh)
+	q.Add(b, PriorityMedium)
+	if q.Len(PriorityHigh) != 0 {
+		t.Fatalf("Expected a resource being processed not to be queued")
+	}
+	q.Done(a)
+	if q.Len(PriorityHigh) != 1 {
+		t.Fatalf("Expected a resource added while being processed to be queued once done")
+	}
+
+	qualifiedName, _ = q.Get()
+	if qualifiedName != a {
+		t.Fatalf("Expected %v, got %v", a, qualifiedName)
+	}
+}
+
+func TestNewWorkPriorities(t *testing.T) {
+	high := fedv1b1.SyncPriorityHigh
+	low := fedv1b1.SyncPriorityLow
+
+	priorities := NewWorkPriorities(nil)
+	if priorities != DefaultWorkPriorities {
+		t.Fatalf("Expected default priorities %v, got %v", DefaultWorkPriorities, priorities)
+	}
+
+	priorities = NewWorkPriorities(&fedv1b1.SyncPriorities{UserChange: &low, Retry: &high})
+	expected := WorkPriorities{UserChange: PriorityLow, ClusterSync: PriorityMedium, Retry: PriorityHigh}
+	if priorities != expected {
+		t.Fatalf("Expected priorities %v, got %v", expected, priorities)
+	}
+	if priorities.Priority(RetryWork) != PriorityHigh {
+		t.Fatalf("Expected retries to have high priority")
+	}
+}
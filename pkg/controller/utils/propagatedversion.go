@@ -74,3 +74,99 @@ func PropagatedVersionStatusEquivalent(pvs1, pvs2 *fedv1a1.PropagatedVersionStat
 		pvs1.OverrideVersion == pvs2.OverrideVersion &&
 		reflect.DeepEqual(pvs1.ClusterVersions, pvs2.ClusterVersions)
 }
+
+// DriftedFields returns the sorted JSON pointers of the fields set in
+// the desired object whose values differ in the cluster object.  Only
+// the labels and annotations of the metadata are compared, and status
+// is ignored.  Arrays are reported as a whole, and their elements are
+// compared over the fields set in the desired elements so that fields
+// defaulted by the server are not reported.
+func DriftedFields(desiredObj, clusterObj *unstructured.Unstructured) []string {
+	var paths []string
+	for key, desiredValue := range desiredObj.Object {
+		switch key {
+		case StatusField:
+			continue
+		case MetadataField:
+			desiredMeta, _ := desiredValue.(map[string]interface{})
+			clusterMeta, _ := clusterObj.Object[MetadataField].(map[string]interface{})
+			for _, metaKey := range []string{"labels", "annotations"} {
+				if value, ok := desiredMeta[metaKey]; ok {
+					paths = appendDriftedFields(paths, "/"+MetadataField+"/"+metaKey, value, clusterMeta[metaKey])
+				}
+			}
+			continue
+		}
+		paths = appendDriftedFields(paths, "/"+escapePathToken(key), desiredValue, clusterObj.Object[key])
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func appendDriftedFields(paths []string, path string, desiredValue, clusterValue interface{}) []string {
+	desiredMap, ok := desiredValue.(map[string]interface{})
+	if !ok {
+		if !valuesEquivalent(desiredValue, clusterValue) {
+			paths = append(paths, path)
+		}
+		return paths
+	}
+	clusterMap, ok := clusterValue.(map[string]interface{})
+	if !ok {
+		return append(paths, path)
+	}
+	for key, value := range desiredMap {
+		paths = appendDriftedFields(paths, path+"/"+escapePathToken(key), value, clusterMap[key])
+	}
+	return paths
+}
+
+// valuesEquivalent compares the desired unstructured value a with the
+// cluster value b, ignoring the fields of maps that are only set in b
+// and treating numbers of different types as equal if their values
+// are.
+func valuesEquivalent(a, b interface{}) bool {
+	switch aValue := a.(type) {
+	case map[string]interface{}:
+		bValue, ok := b.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range aValue {
+			if !valuesEquivalent(value, bValue[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bValue, ok := b.([]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		for i := range aValue {
+			if !valuesEquivalent(aValue[i], bValue[i]) {
+				return false
+			}
+		}
+		return true
+	case int64, float64:
+		aNumber, _ := toFloat64(a)
+		bNumber, ok := toFloat64(b)
+		return ok && aNumber == bNumber
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return float64(number), true
+	case float64:
+		return number, true
+	}
+	return 0, false
+}
+
+func escapePathToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
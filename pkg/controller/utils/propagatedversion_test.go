@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDriftedFields(t *testing.T) {
+	desiredObj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":   "foo",
+				"labels": map[string]interface{}{"app": "foo"},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "foo", "image": "foo:v1"},
+						},
+					},
+				},
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"app/name": "foo"},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		mutate         func(obj *unstructured.Unstructured)
+		expectedFields []string
+	}{
+		"no drift": {
+			mutate: func(obj *unstructured.Unstructured) {
+				obj.SetResourceVersion("42")
+				obj.SetAnnotations(map[string]string{"foo": "bar"})
+				obj.Object["status"] = map[string]interface{}{"replicas": int64(1)}
+				_ = unstructured.SetNestedField(obj.Object, "RollingUpdate", "spec", "strategy", "type")
+			},
+		},
+		"numbers of different types": {
+			mutate: func(obj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(obj.Object, float64(1), "spec", "replicas")
+			},
+		},
+		"fields defaulted by the server": {
+			mutate: func(obj *unstructured.Unstructured) {
+				containers := []interface{}{
+					map[string]interface{}{
+						"name":                     "foo",
+						"image":                    "foo:v1",
+						"imagePullPolicy":          "IfNotPresent",
+						"terminationMessagePath":   "/dev/termination-log",
+						"terminationMessagePolicy": "File",
+						"resources":                map[string]interface{}{},
+					},
+				}
+				_ = unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+			},
+		},
+		"added array element": {
+			mutate: func(obj *unstructured.Unstructured) {
+				containers := []interface{}{
+					map[string]interface{}{"name": "foo", "image": "foo:v1"},
+					map[string]interface{}{"name": "bar", "image": "bar:v1"},
+				}
+				_ = unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+			},
+			expectedFields: []string{"/spec/template/spec/containers"},
+		},
+		"drifted fields": {
+			mutate: func(obj *unstructured.Unstructured) {
+				obj.SetLabels(map[string]string{"app": "bar"})
+				_ = unstructured.SetNestedField(obj.Object, int64(3), "spec", "replicas")
+				_ = unstructured.SetNestedField(obj.Object, "bar", "spec", "selector", "matchLabels", "app/name")
+				containers := []interface{}{
+					map[string]interface{}{"name": "foo", "image": "foo:v2"},
+				}
+				_ = unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+			},
+			expectedFields: []string{
+				"/metadata/labels/app",
+				"/spec/replicas",
+				"/spec/selector/matchLabels/app~1name",
+				"/spec/template/spec/containers",
+			},
+		},
+		"removed field": {
+			mutate: func(obj *unstructured.Unstructured) {
+				unstructured.RemoveNestedField(obj.Object, "spec", "selector")
+			},
+			expectedFields: []string{"/spec/selector"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			clusterObj := desiredObj.DeepCopy()
+			testCase.mutate(clusterObj)
+			fields := DriftedFields(desiredObj, clusterObj)
+			if !reflect.DeepEqual(fields, testCase.expectedFields) {
+				t.Fatalf("Expected drifted fields %v, got %v", testCase.expectedFields, fields)
+			}
+		})
+	}
+}
@@ -52,22 +52,44 @@ func newResourceInformer(client ResourceClient, namespace string, apiResource *m
 		obj.SetGroupVersionKind(gvk)
 	}
 	return cache.NewInformer(
-		&cache.ListWatch{
-			ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
-				options.LabelSelector = labelSelector
-				return client.Resources(namespace).List(context.Background(), options)
-			},
-			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-				options.LabelSelector = labelSelector
-				return client.Resources(namespace).Watch(context.Background(), options)
-			},
-		},
+		newResourceListWatch(client, namespace, labelSelector),
 		obj, // use an unstructured type with apiVersion / kind populated for informer logging purposes
 		NoResyncPeriod,
 		NewTriggerOnAllChanges(triggerFunc),
 	)
 }
 
+// NewManagedResourceSharedInformer returns an informer limited to
+// resources managed by KubeFed whose store and watch can be shared by
+// multiple event handlers.
+func NewManagedResourceSharedInformer(client ResourceClient, namespace string, apiResource *metav1.APIResource) cache.SharedIndexInformer {
+	labelSelector := labels.Set(map[string]string{ManagedByKubeFedLabelKey: ManagedByKubeFedLabelValue}).AsSelector().String()
+	obj := &unstructured.Unstructured{}
+	if apiResource != nil {
+		gvk := schema.GroupVersionKind{Group: apiResource.Group, Version: apiResource.Version, Kind: apiResource.Kind}
+		obj.SetGroupVersionKind(gvk)
+	}
+	return cache.NewSharedIndexInformer(
+		newResourceListWatch(client, namespace, labelSelector),
+		obj,
+		NoResyncPeriod,
+		cache.Indexers{},
+	)
+}
+
+func newResourceListWatch(client ResourceClient, namespace, labelSelector string) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (pkgruntime.Object, error) {
+			options.LabelSelector = labelSelector
+			return client.Resources(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = labelSelector
+			return client.Resources(namespace).Watch(context.Background(), options)
+		},
+	}
+}
+
 func ObjFromCache(store cache.Store, kind, key string) (*unstructured.Unstructured, error) {
 	obj, err := rawObjFromCache(store, kind, key)
 	if err != nil {
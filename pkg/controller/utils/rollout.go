@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+// GenericRolloutStrategy determines the order in which changes to a
+// federated resource are propagated to the selected clusters.
+type GenericRolloutStrategy struct {
+	// Waves are groups of clusters that are updated in order.  A wave
+	// is only started once the clusters of the previous waves are
+	// updated and healthy.  Clusters in no wave form a final wave.
+	Waves []GenericRolloutWave `json:"waves,omitempty"`
+	// MaxUnavailableClusters is the maximum number of clusters that
+	// may be updated but not yet healthy at any one time.
+	MaxUnavailableClusters *int64 `json:"maxUnavailableClusters,omitempty"`
+}
+
+// GenericRolloutWave selects the clusters of a rollout wave by name or
+// by label.
+type GenericRolloutWave struct {
+	Clusters        []GenericClusterReference `json:"clusters,omitempty"`
+	ClusterSelector *metav1.LabelSelector     `json:"clusterSelector,omitempty"`
+}
+
+type GenericRolloutSpec struct {
+	RolloutStrategy *GenericRolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+type GenericRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GenericRolloutSpec `json:"spec,omitempty"`
+}
+
+// GetRolloutStrategy returns the rollout strategy of the given
+// federated resource, or nil if none is specified.
+func GetRolloutStrategy(fedObject *unstructured.Unstructured) (*GenericRolloutStrategy, error) {
+	rollout := &GenericRollout{}
+	err := UnstructuredToInterface(fedObject, rollout)
+	if err != nil {
+		return nil, err
+	}
+	return rollout.Spec.RolloutStrategy, nil
+}
+
+// WaveForCluster returns the index of the first wave that selects the
+// given cluster.  A cluster selected by no wave is in the final wave,
+// whose index is the number of waves.
+func (s *GenericRolloutStrategy) WaveForCluster(cluster *fedv1b1.KubeFedCluster) (int, error) {
+	for i, wave := range s.Waves {
+		for _, clusterRef := range wave.Clusters {
+			if clusterRef.Name == cluster.Name {
+				return i, nil
+			}
+		}
+		selector, err := metav1.LabelSelectorAsSelector(wave.ClusterSelector)
+		if err != nil {
+			return 0, err
+		}
+		if selector.Matches(labels.Set(cluster.Labels)) {
+			return i, nil
+		}
+	}
+	return len(s.Waves), nil
+}
+
+// IsObjectHealthy returns whether the given cluster object has been
+// fully rolled out according to its status.  The status of workloads
+// is checked for updated and available replicas, and that of other
+// objects for a Ready condition if they report one.  An object whose
+// status does not reflect its latest generation is never healthy.
+func IsObjectHealthy(clusterObj *unstructured.Unstructured) bool {
+	observedGeneration, ok, _ := unstructured.NestedInt64(clusterObj.Object, StatusField, "observedGeneration")
+	if ok && observedGeneration < clusterObj.GetGeneration() {
+		return false
+	}
+
+	// Counts are omitted from the status of workloads when zero.
+	statusCount := func(field string) int64 {
+		count, _, _ := unstructured.NestedInt64(clusterObj.Object, StatusField, field)
+		return count
+	}
+	replicas, ok, _ := unstructured.NestedInt64(clusterObj.Object, SpecField, ReplicasField)
+	if !ok {
+		replicas = 1
+	}
+	switch clusterObj.GetKind() {
+	case "Deployment":
+		return statusCount("updatedReplicas") >= replicas && statusCount("availableReplicas") >= replicas &&
+			statusCount(ReplicasField) == statusCount("updatedReplicas")
+	case "StatefulSet":
+		return statusCount("updatedReplicas") >= replicas && statusCount("readyReplicas") >= replicas
+	case "ReplicaSet":
+		return statusCount("availableReplicas") >= replicas
+	case "DaemonSet":
+		desired := statusCount("desiredNumberScheduled")
+		return statusCount("updatedNumberScheduled") >= desired && statusCount("numberAvailable") >= desired
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(clusterObj.Object, StatusField, "conditions")
+	for _, rawCondition := range conditions {
+		condition, ok := rawCondition.(map[string]interface{})
+		if ok && condition["type"] == "Ready" {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestIsObjectHealthy(t *testing.T) {
+	testCases := map[string]struct {
+		kind     string
+		spec     map[string]interface{}
+		status   map[string]interface{}
+		expected bool
+	}{
+		"deployment rolled out": {
+			kind:     "Deployment",
+			spec:     map[string]interface{}{"replicas": int64(2)},
+			status:   map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)},
+			expected: true,
+		},
+		"deployment with old replicas": {
+			kind:   "Deployment",
+			spec:   map[string]interface{}{"replicas": int64(2)},
+			status: map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(2), "availableReplicas": int64(3)},
+		},
+		"deployment with stale status": {
+			kind:   "Deployment",
+			spec:   map[string]interface{}{"replicas": int64(2)},
+			status: map[string]interface{}{"observedGeneration": int64(1), "replicas": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)},
+		},
+		"deployment scaled to zero": {
+			kind:     "Deployment",
+			spec:     map[string]interface{}{"replicas": int64(0)},
+			status:   map[string]interface{}{"observedGeneration": int64(2)},
+			expected: true,
+		},
+		"daemonset not available": {
+			kind:   "DaemonSet",
+			status: map[string]interface{}{"desiredNumberScheduled": int64(3), "updatedNumberScheduled": int64(3), "numberAvailable": int64(2)},
+		},
+		"object without status": {
+			kind:     "ConfigMap",
+			expected: true,
+		},
+		"object not ready": {
+			kind: "Certificate",
+			status: map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False"},
+				},
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			obj.SetKind(testCase.kind)
+			obj.SetGeneration(2)
+			if testCase.spec != nil {
+				obj.Object[SpecField] = testCase.spec
+			}
+			if testCase.status != nil {
+				obj.Object[StatusField] = testCase.status
+			}
+			if healthy := IsObjectHealthy(obj); healthy != testCase.expected {
+				t.Fatalf("Expected healthy to be %v, got %v", testCase.expected, healthy)
+			}
+		})
+	}
+}
+
+func TestWaveForCluster(t *testing.T) {
+	strategy := &GenericRolloutStrategy{
+		Waves: []GenericRolloutWave{
+			{Clusters: []GenericClusterReference{{Name: "canary"}}},
+			{ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "staging"}}},
+		},
+	}
+	testCases := map[string]struct {
+		labels   map[string]string
+		expected int
+	}{
+		"canary":  {expected: 0},
+		"staging": {labels: map[string]string{"env": "staging"}, expected: 1},
+		"prod":    {labels: map[string]string{"env": "prod"}, expected: 2},
+	}
+	for clusterName, testCase := range testCases {
+		t.Run(clusterName, func(t *testing.T) {
+			cluster := &fedv1b1.KubeFedCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Labels: testCase.labels},
+			}
+			wave, err := strategy.WaveForCluster(cluster)
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			if wave != testCase.expected {
+				t.Fatalf("Expected wave %d, got %d", testCase.expected, wave)
+			}
+		})
+	}
+}
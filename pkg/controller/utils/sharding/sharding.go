@@ -0,0 +1,432 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/klog/v2"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+const (
+	// RoleLabel distinguishes the Leases of the members of the
+	// controller manager from the Leases of shards.
+	RoleLabel = "kubefed.io/sharding"
+
+	memberRole = "member"
+	shardRole  = "shard"
+
+	leasePrefix       = "kubefed-controller-manager-"
+	memberLeasePrefix = leasePrefix + "member-"
+	shardLeasePrefix  = leasePrefix + "shard-"
+)
+
+// Options configures the Leases through which a Manager holds shards.
+type Options struct {
+	// Namespace of the Leases, typically the KubeFed namespace.
+	Namespace string
+	Mode      fedv1b1.ShardingMode
+	Shards    int
+	// LeaseDuration is the duration after its last renewal that a
+	// Lease is considered held by its holder.
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration after its last successful renewal
+	// that a shard is no longer considered owned by this replica.  It
+	// must be less than LeaseDuration so that a replica stops
+	// reconciling a shard before another replica may acquire it.
+	RenewDeadline time.Duration
+	// RetryPeriod is the interval between renewals and attempts to
+	// acquire shards.
+	RetryPeriod time.Duration
+}
+
+// Manager splits the reconciliation of federated resources between
+// the replicas of the controller manager.  Federated resources are
+// assigned to shards by a hash of their key, and each shard is held by
+// a single replica through a Lease.  Every replica also holds a member
+// Lease so that the number of live replicas is known, and holds an even
+// share of the shards: shards are released when a replica joins and
+// acquired from a replica whose Leases have expired.
+type Manager struct {
+	client   coordinationv1client.LeasesGetter
+	identity string
+	opts     Options
+
+	// now is replaceable for testing.
+	now func() time.Time
+
+	lock sync.RWMutex
+	// owned maps the shards held by this replica to the time of their
+	// last successful renewal.
+	owned map[int]time.Time
+
+	listenerLock sync.Mutex
+	listeners    map[int]func()
+	nextListener int
+}
+
+// NewManager returns a manager holding shards on behalf of the replica
+// with the given identity.
+func NewManager(client coordinationv1client.LeasesGetter, identity string, opts Options) *Manager {
+	return &Manager{
+		client:    client,
+		identity:  identity,
+		opts:      opts,
+		now:       time.Now,
+		owned:     make(map[int]time.Time),
+		listeners: make(map[int]func()),
+	}
+}
+
+// Run acquires and renews shards until the stop channel is closed, and
+// then releases the shards held by this replica.
+func (m *Manager) Run(stopChan <-chan struct{}) {
+	klog.Infof("Starting shard manager %q for %d shards by %s", m.identity, m.opts.Shards, m.opts.Mode)
+	wait.Until(func() {
+		m.sync(context.TODO())
+	}, m.opts.RetryPeriod, stopChan)
+	m.releaseAll(context.TODO())
+}
+
+// Owns returns whether the shard of the given key is held by this
+// replica.  A nil manager owns every key.
+func (m *Manager) Owns(key string) bool {
+	if m == nil {
+		return true
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	_, ok := m.owned[m.shardFor(key)]
+	return ok
+}
+
+// OwnsTypeConfig returns whether the federated resources of the named
+// FederatedTypeConfig may be reconciled by this replica.
+func (m *Manager) OwnsTypeConfig(name string) bool {
+	if m == nil || m.opts.Mode != fedv1b1.ShardingModeTypeConfig {
+		return true
+	}
+	return m.Owns(name)
+}
+
+// OwnsResource returns whether the federated resource with the given
+// namespace/name key may be reconciled by this replica.
+func (m *Manager) OwnsResource(key string) bool {
+	if m == nil || m.opts.Mode != fedv1b1.ShardingModeResource {
+		return true
+	}
+	return m.Owns(key)
+}
+
+// AddListener adds a function to call when shards are acquired or
+// released by this replica.  The returned function removes the
+// listener.
+func (m *Manager) AddListener(listener func()) func() {
+	if m == nil {
+		return func() {}
+	}
+	m.listenerLock.Lock()
+	defer m.listenerLock.Unlock()
+	id := m.nextListener
+	m.nextListener++
+	m.listeners[id] = listener
+	return func() {
+		m.listenerLock.Lock()
+		defer m.listenerLock.Unlock()
+		delete(m.listeners, id)
+	}
+}
+
+func (m *Manager) shardFor(key string) int {
+	return int(hash(key) % uint32(m.opts.Shards))
+}
+
+// sync renews the member Lease of this replica and balances the shards
+// held by this replica against the number of live replicas.
+func (m *Manager) sync(ctx context.Context) {
+	now := m.now()
+	before := m.ownedShards()
+
+	if err := m.renewMember(ctx, now); err != nil {
+		klog.Errorf("Failed to renew member lease of %q: %v", m.identity, err)
+	}
+
+	leases, err := m.client.Leases(m.opts.Namespace).List(ctx, metav1.ListOptions{LabelSelector: RoleLabel})
+	if err != nil {
+		klog.Errorf("Failed to list shard leases: %v", err)
+		m.expireShards(now)
+		m.notifyIfChanged(before)
+		return
+	}
+	members := 0
+	shardLeases := make(map[int]*coordinationv1.Lease)
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		switch lease.Labels[RoleLabel] {
+		case memberRole:
+			if holder(lease) == m.identity || !m.expired(lease, now) {
+				members++
+			}
+		case shardRole:
+			index, err := strconv.Atoi(strings.TrimPrefix(lease.Name, shardLeasePrefix))
+			if err == nil && index >= 0 && index < m.opts.Shards {
+				shardLeases[index] = lease
+			}
+		}
+	}
+	if members == 0 {
+		members = 1
+	}
+	target := (m.opts.Shards + members - 1) / members
+
+	// Start from an offset specific to this replica so that replicas
+	// starting together do not contend for the same shards.
+	offset := int(hash(m.identity) % uint32(m.opts.Shards))
+	held := 0
+	for j := 0; j < m.opts.Shards; j++ {
+		index := (offset + j) % m.opts.Shards
+		lease := shardLeases[index]
+		if lease == nil || holder(lease) != m.identity {
+			continue
+		}
+		if held >= target {
+			m.release(ctx, index, lease)
+			continue
+		}
+		if err := m.renewShard(ctx, lease, now); err != nil {
+			klog.V(2).Infof("Failed to renew lease of shard %d: %v", index, err)
+			continue
+		}
+		m.setOwned(index, now)
+		held++
+	}
+	for j := 0; j < m.opts.Shards && held < target; j++ {
+		index := (offset + j) % m.opts.Shards
+		lease := shardLeases[index]
+		if lease != nil && (holder(lease) == m.identity || (holder(lease) != "" && !m.expired(lease, now))) {
+			continue
+		}
+		if err := m.acquire(ctx, index, lease, now); err != nil {
+			klog.V(2).Infof("Failed to acquire lease of shard %d: %v", index, err)
+			continue
+		}
+		klog.V(2).Infof("Acquired shard %d", index)
+		m.setOwned(index, now)
+		held++
+	}
+
+	m.expireShards(now)
+	m.notifyIfChanged(before)
+}
+
+func (m *Manager) renewMember(ctx context.Context, now time.Time) error {
+	name := fmt.Sprintf("%s%08x", memberLeasePrefix, hash(m.identity))
+	leases := m.client.Leases(m.opts.Namespace)
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = m.newLease(name, memberRole, now)
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	lease.Spec.HolderIdentity = &m.identity
+	m.setRenewed(lease, now)
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func (m *Manager) renewShard(ctx context.Context, lease *coordinationv1.Lease, now time.Time) error {
+	lease = lease.DeepCopy()
+	m.setRenewed(lease, now)
+	_, err := m.client.Leases(m.opts.Namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// acquire takes the lease of the given shard.  The update of an
+// existing lease only succeeds if the lease has not been changed since
+// it was listed, so that a shard is acquired by a single replica.
+func (m *Manager) acquire(ctx context.Context, index int, lease *coordinationv1.Lease, now time.Time) error {
+	leases := m.client.Leases(m.opts.Namespace)
+	if lease == nil {
+		_, err := leases.Create(ctx, m.newLease(fmt.Sprintf("%s%d", shardLeasePrefix, index), shardRole, now), metav1.CreateOptions{})
+		return err
+	}
+	lease = lease.DeepCopy()
+	lease.Spec.HolderIdentity = &m.identity
+	acquireTime := metav1.NewMicroTime(now)
+	lease.Spec.AcquireTime = &acquireTime
+	transitions := int32(1)
+	if lease.Spec.LeaseTransitions != nil {
+		transitions += *lease.Spec.LeaseTransitions
+	}
+	lease.Spec.LeaseTransitions = &transitions
+	m.setRenewed(lease, now)
+	_, err := leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// release stops reconciling the given shard and then clears the holder
+// of its lease so that it may be acquired by another replica without
+// waiting for the lease to expire.
+func (m *Manager) release(ctx context.Context, index int, lease *coordinationv1.Lease) {
+	m.lock.Lock()
+	delete(m.owned, index)
+	m.lock.Unlock()
+
+	lease = lease.DeepCopy()
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.AcquireTime = nil
+	if _, err := m.client.Leases(m.opts.Namespace).Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		klog.V(2).Infof("Failed to release lease of shard %d: %v", index, err)
+		return
+	}
+	klog.V(2).Infof("Released shard %d", index)
+}
+
+func (m *Manager) releaseAll(ctx context.Context) {
+	before := m.ownedShards()
+	leases := m.client.Leases(m.opts.Namespace)
+	for index := range before {
+		lease, err := leases.Get(ctx, fmt.Sprintf("%s%d", shardLeasePrefix, index), metav1.GetOptions{})
+		if err != nil || holder(lease) != m.identity {
+			m.lock.Lock()
+			delete(m.owned, index)
+			m.lock.Unlock()
+			continue
+		}
+		m.release(ctx, index, lease)
+	}
+	name := fmt.Sprintf("%s%08x", memberLeasePrefix, hash(m.identity))
+	if err := leases.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Failed to delete member lease of %q: %v", m.identity, err)
+	}
+	m.notifyIfChanged(before)
+}
+
+func (m *Manager) newLease(name, role string, now time.Time) *coordinationv1.Lease {
+	acquireTime := metav1.NewMicroTime(now)
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: m.opts.Namespace,
+			Labels:    map[string]string{RoleLabel: role},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity: &m.identity,
+			AcquireTime:    &acquireTime,
+		},
+	}
+	m.setRenewed(lease, now)
+	return lease
+}
+
+func (m *Manager) setRenewed(lease *coordinationv1.Lease, now time.Time) {
+	renewTime := metav1.NewMicroTime(now)
+	lease.Spec.RenewTime = &renewTime
+	durationSeconds := int32(m.opts.LeaseDuration / time.Second)
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+}
+
+func (m *Manager) expired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	duration := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	return lease.Spec.RenewTime.Add(duration).Before(now)
+}
+
+func (m *Manager) setOwned(index int, now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.owned[index] = now
+}
+
+// expireShards stops reconciling the shards that could not be renewed
+// within the renew deadline, since another replica may acquire them
+// once their lease expires.
+func (m *Manager) expireShards(now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for index, renewed := range m.owned {
+		if now.Sub(renewed) > m.opts.RenewDeadline {
+			klog.Warningf("Lost shard %d after failing to renew its lease", index)
+			delete(m.owned, index)
+		}
+	}
+}
+
+func (m *Manager) ownedShards() map[int]bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	shards := make(map[int]bool, len(m.owned))
+	for index := range m.owned {
+		shards[index] = true
+	}
+	return shards
+}
+
+func (m *Manager) notifyIfChanged(before map[int]bool) {
+	after := m.ownedShards()
+	changed := len(before) != len(after)
+	for index := range after {
+		if !before[index] {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	klog.Infof("Shard manager %q now holds %d of %d shards", m.identity, len(after), m.opts.Shards)
+
+	m.listenerLock.Lock()
+	listeners := make([]func(), 0, len(m.listeners))
+	for _, listener := range m.listeners {
+		listeners = append(listeners, listener)
+	}
+	m.listenerLock.Unlock()
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+func holder(lease *coordinationv1.Lease) string {
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+func hash(key string) uint32 {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(key))
+	return hasher.Sum32()
+}
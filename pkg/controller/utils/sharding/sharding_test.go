@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+const testShards = 16
+
+type testClock struct {
+	now time.Time
+}
+
+func newTestManagers(identities ...string) ([]*Manager, *testClock) {
+	client := fake.NewClientset()
+	clock := &testClock{now: time.Now()}
+	var managers []*Manager
+	for _, identity := range identities {
+		m := NewManager(client.CoordinationV1(), identity, Options{
+			Namespace:     "kube-federation-system",
+			Mode:          fedv1b1.ShardingModeResource,
+			Shards:        testShards,
+			LeaseDuration: 15 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   5 * time.Second,
+		})
+		m.now = func() time.Time { return clock.now }
+		managers = append(managers, m)
+	}
+	return managers, clock
+}
+
+// syncAll runs the given managers until their shards are balanced.
+func syncAll(managers []*Manager) {
+	for i := 0; i < 3; i++ {
+		for _, m := range managers {
+			m.sync(context.TODO())
+		}
+	}
+}
+
+func ownedCounts(t *testing.T, managers []*Manager) []int {
+	counts := make([]int, len(managers))
+	for index := 0; index < testShards; index++ {
+		owners := 0
+		for i, m := range managers {
+			m.lock.RLock()
+			_, ok := m.owned[index]
+			m.lock.RUnlock()
+			if ok {
+				owners++
+				counts[i]++
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("Expected shard %d to be owned by a single replica, got %d", index, owners)
+		}
+	}
+	return counts
+}
+
+func TestManagerBalancesShards(t *testing.T) {
+	managers, _ := newTestManagers("replica1")
+	syncAll(managers)
+	if counts := ownedCounts(t, managers); counts[0] != testShards {
+		t.Fatalf("Expected a single replica to own all shards, got %v", counts)
+	}
+
+	notified := 0
+	managers[0].AddListener(func() { notified++ })
+
+	// A replica joining with the same client shares the leases.
+	joined, clock := newTestManagers("replica2")
+	joined[0].client = managers[0].client
+	managers[0].now = func() time.Time { return clock.now }
+	managers = append(managers, joined[0])
+	syncAll(managers)
+	for _, count := range ownedCounts(t, managers) {
+		if count != testShards/2 {
+			t.Fatalf("Expected shards to be split evenly, got %v", ownedCounts(t, managers))
+		}
+	}
+	if notified == 0 {
+		t.Fatalf("Expected listeners to be notified of released shards")
+	}
+}
+
+func TestManagerRebalancesOnReplicaFailure(t *testing.T) {
+	managers, clock := newTestManagers("replica1", "replica2", "replica3")
+	syncAll(managers)
+	ownedCounts(t, managers)
+
+	// replica3 stops renewing its leases.
+	clock.now = clock.now.Add(20 * time.Second)
+	survivors := managers[:2]
+	syncAll(survivors)
+	for _, count := range ownedCounts(t, survivors) {
+		if count != testShards/2 {
+			t.Fatalf("Expected the shards of a failed replica to be acquired, got %v", ownedCounts(t, survivors))
+		}
+	}
+
+	// The failed replica stops reconciling once it misses the renew
+	// deadline, even if it cannot reach the API server.
+	managers[2].expireShards(clock.now)
+	if len(managers[2].ownedShards()) != 0 {
+		t.Fatalf("Expected a replica unable to renew its leases to release its shards")
+	}
+}
+
+func TestManagerOwnership(t *testing.T) {
+	var unsharded *Manager
+	if !unsharded.OwnsResource("ns/foo") || !unsharded.OwnsTypeConfig("deployments.apps") {
+		t.Fatalf("Expected every resource to be owned without sharding")
+	}
+
+	managers, _ := newTestManagers("replica1", "replica2")
+	syncAll(managers)
+	for _, key := range []string{"ns/foo", "ns/bar", "other/foo"} {
+		owners := 0
+		for _, m := range managers {
+			if m.OwnsResource(key) {
+				owners++
+			}
+			if !m.OwnsTypeConfig(key) {
+				t.Fatalf("Expected every type to be owned when sharding by resource")
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("Expected %q to be owned by a single replica, got %d", key, owners)
+		}
+	}
+}
@@ -21,7 +21,6 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/flowcontrol"
-	"k8s.io/client-go/util/workqueue"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/kubefed/pkg/metrics"
@@ -36,6 +35,7 @@ type ReconcileWorker interface {
 	EnqueueForRetry(qualifiedName QualifiedName)
 	EnqueueObject(obj runtimeclient.Object)
 	EnqueueWithDelay(qualifiedName QualifiedName, delay time.Duration)
+	EnqueueWithClass(qualifiedName QualifiedName, class WorkClass, delay time.Duration)
 	Run(stopChan <-chan struct{})
 	SetDelay(retryDelay, clusterSyncDelay time.Duration)
 }
@@ -45,6 +45,10 @@ type WorkerOptions struct {
 
 	// MaxConcurrentReconciles is the maximum number of concurrent Reconciles which can be run. Defaults to 1.
 	MaxConcurrentReconciles int
+
+	// Priorities assigns a priority to each class of work. Defaults to
+	// DefaultWorkPriorities.
+	Priorities *WorkPriorities
 }
 
 type WorkerTiming struct {
@@ -70,8 +74,11 @@ type asyncWorker struct {
 	// of a member cluster.
 	deliverer *DelayingDeliverer
 
-	// Work queue allowing parallel processing of resources
-	queue workqueue.Interface
+	// Work queue allowing parallel processing of resources in the
+	// order of their priority
+	queue *priorityQueue
+
+	priorities WorkPriorities
 
 	// Backoff manager
 	backoff *flowcontrol.Backoff
@@ -93,31 +100,35 @@ func NewReconcileWorker(name string, reconcile ReconcileFunc, options WorkerOpti
 	if options.MaxConcurrentReconciles == 0 {
 		options.MaxConcurrentReconciles = 1
 	}
+	if options.Priorities == nil {
+		options.Priorities = &DefaultWorkPriorities
+	}
 	return &asyncWorker{
 		name:                    name,
 		reconcile:               reconcile,
 		timing:                  options.WorkerTiming,
 		maxConcurrentReconciles: options.MaxConcurrentReconciles,
 		deliverer:               NewDelayingDeliverer(),
-		queue:                   workqueue.NewNamed(name),
+		queue:                   newPriorityQueue(name),
+		priorities:              *options.Priorities,
 		backoff:                 flowcontrol.NewBackOff(options.InitialBackoff, options.MaxBackoff),
 	}
 }
 
 func (w *asyncWorker) Enqueue(qualifiedName QualifiedName) {
-	w.deliver(qualifiedName, 0, false)
+	w.deliver(qualifiedName, UserChangeWork, 0, false)
 }
 
 func (w *asyncWorker) EnqueueForError(qualifiedName QualifiedName) {
-	w.deliver(qualifiedName, 0, true)
+	w.deliver(qualifiedName, RetryWork, 0, true)
 }
 
 func (w *asyncWorker) EnqueueForRetry(qualifiedName QualifiedName) {
-	w.deliver(qualifiedName, w.timing.RetryDelay, false)
+	w.deliver(qualifiedName, RetryWork, w.timing.RetryDelay, false)
 }
 
 func (w *asyncWorker) EnqueueForClusterSync(qualifiedName QualifiedName) {
-	w.deliver(qualifiedName, w.timing.ClusterSyncDelay, false)
+	w.deliver(qualifiedName, ClusterSyncWork, w.timing.ClusterSyncDelay, false)
 }
 
 func (w *asyncWorker) EnqueueObject(obj runtimeclient.Object) {
@@ -126,7 +137,11 @@ func (w *asyncWorker) EnqueueObject(obj runtimeclient.Object) {
 }
 
 func (w *asyncWorker) EnqueueWithDelay(qualifiedName QualifiedName, delay time.Duration) {
-	w.deliver(qualifiedName, delay, false)
+	w.deliver(qualifiedName, RetryWork, delay, false)
+}
+
+func (w *asyncWorker) EnqueueWithClass(qualifiedName QualifiedName, class WorkClass, delay time.Duration) {
+	w.deliver(qualifiedName, class, delay, false)
 }
 
 func (w *asyncWorker) Run(stopChan <-chan struct{}) {
@@ -134,9 +149,9 @@ func (w *asyncWorker) Run(stopChan <-chan struct{}) {
 
 	StartBackoffGC(w.backoff, stopChan)
 	w.deliverer.StartWithHandler(func(item *DelayingDelivererItem) {
-		qualifiedName, ok := item.Value.(*QualifiedName)
+		work, ok := item.Value.(*workItem)
 		if ok {
-			w.queue.Add(*qualifiedName)
+			w.queue.Add(work.qualifiedName, w.priorities.Priority(work.class))
 		}
 	})
 
@@ -157,9 +172,15 @@ func (w *asyncWorker) SetDelay(retryDelay, clusterSyncDelay time.Duration) {
 	w.timing.ClusterSyncDelay = clusterSyncDelay
 }
 
+// workItem is a resource to reconcile and the reason it was enqueued.
+type workItem struct {
+	qualifiedName QualifiedName
+	class         WorkClass
+}
+
 // deliver adds backoff to delay if this delivery is related to some
 // failure. Resets backoff if there was no failure.
-func (w *asyncWorker) deliver(qualifiedName QualifiedName, delay time.Duration, failed bool) {
+func (w *asyncWorker) deliver(qualifiedName QualifiedName, class WorkClass, delay time.Duration, failed bool) {
 	key := qualifiedName.String()
 	if failed {
 		w.backoff.Next(key, time.Now())
@@ -167,7 +188,7 @@ func (w *asyncWorker) deliver(qualifiedName QualifiedName, delay time.Duration,
 	} else {
 		w.backoff.Reset(key)
 	}
-	w.deliverer.DeliverAfter(key, &qualifiedName, delay)
+	w.deliverer.DeliverAfter(key, &workItem{qualifiedName: qualifiedName, class: class}, delay)
 }
 
 func (w *asyncWorker) worker() {
@@ -176,16 +197,11 @@ func (w *asyncWorker) worker() {
 }
 
 func (w *asyncWorker) reconcileOnce() bool {
-	obj, quit := w.queue.Get()
+	qualifiedName, quit := w.queue.Get()
 	if quit {
 		return false
 	}
-	defer w.queue.Done(obj)
-
-	qualifiedName, ok := obj.(QualifiedName)
-	if !ok {
-		return true
-	}
+	defer w.queue.Done(qualifiedName)
 
 	metrics.ControllerRuntimeActiveWorkers.WithLabelValues(w.name).Add(1)
 	defer metrics.ControllerRuntimeActiveWorkers.WithLabelValues(w.name).Add(-1)
@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedresource
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+// ValidateOverrides validates the overrides of the given federated
+// resource against the schema of the properties of its target type.
+// The path of a JSON patch override must exist in the schema and the
+// value it sets must be of the type defined by the schema.  Patch
+// documents are validated in the same way.  Fields whose schema is not
+// known, e.g. fields preserving unknown fields, accept any value.
+func ValidateOverrides(fedObject *unstructured.Unstructured, templateSchema map[string]apiextv1.JSONSchemaProps) field.ErrorList {
+	allErrs := field.ErrorList{}
+	overridesPath := field.NewPath(utils.SpecField, utils.OverridesField)
+
+	if _, err := utils.GetOverrides(fedObject); err != nil {
+		return append(allErrs, field.Invalid(overridesPath, field.OmitValueType{}, err.Error()))
+	}
+	if _, err := utils.GetSelectorOverrides(fedObject); err != nil {
+		return append(allErrs, field.Invalid(overridesPath, field.OmitValueType{}, err.Error()))
+	}
+
+	genericFedObject := utils.GenericOverride{}
+	if err := utils.UnstructuredToInterface(fedObject, &genericFedObject); err != nil {
+		return append(allErrs, field.Invalid(overridesPath, field.OmitValueType{}, err.Error()))
+	}
+	if genericFedObject.Spec == nil {
+		return allErrs
+	}
+
+	rootSchema := &apiextv1.JSONSchemaProps{
+		Type:       "object",
+		Properties: templateSchema,
+	}
+	for i, overrideItem := range genericFedObject.Spec.Overrides {
+		for j, clusterOverride := range overrideItem.ClusterOverrides {
+			fldPath := overridesPath.Index(i).Child(utils.ClusterOverridesField).Index(j)
+			if clusterOverride.IsPatchDocument() {
+				allErrs = append(allErrs, validateValue(clusterOverride.Patch, rootSchema, true, fldPath.Child("patch"))...)
+				continue
+			}
+			allErrs = append(allErrs, validateJSONPatchOverride(clusterOverride, rootSchema, fldPath)...)
+		}
+	}
+	return allErrs
+}
+
+// validateJSONPatchOverride validates that the path of the given JSON
+// patch override exists in the schema and that the value it adds or
+// replaces is of the type defined for the path.
+func validateJSONPatchOverride(clusterOverride utils.ClusterOverride, rootSchema *apiextv1.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	path := clusterOverride.Path
+	if !strings.HasPrefix(path, "/") {
+		return field.ErrorList{field.Invalid(fldPath.Child("path"), path, "must be a JSON pointer starting with '/'")}
+	}
+
+	schema := rootSchema
+	for _, token := range strings.Split(path[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		var err error
+		schema, err = childSchema(schema, token)
+		if err != nil {
+			return field.ErrorList{field.Invalid(fldPath.Child("path"), path, err.Error())}
+		}
+	}
+
+	switch clusterOverride.Op {
+	case "", "add", "replace":
+		return validateValue(clusterOverride.Value, schema, false, fldPath.Child("value"))
+	}
+	return nil
+}
+
+// childSchema returns the schema of the field or array element with
+// the given key.  A nil schema indicates that the schema of the field
+// is not known.
+func childSchema(schema *apiextv1.JSONSchemaProps, key string) (*apiextv1.JSONSchemaProps, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	if isIntOrString(schema) {
+		return nil, fmt.Errorf("%q cannot be traversed since its parent is not an object or array", key)
+	}
+	switch schema.Type {
+	case "object", "":
+		if propertySchema, ok := schema.Properties[key]; ok {
+			return &propertySchema, nil
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Allows {
+			return schema.AdditionalProperties.Schema, nil
+		}
+		if preservesUnknownFields(schema) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("field %q is not defined by the schema of the target type", key)
+	case "array":
+		if key != "-" {
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("%q is not a valid array index", key)
+			}
+		}
+		if schema.Items == nil {
+			return nil, nil
+		}
+		return schema.Items.Schema, nil
+	default:
+		return nil, fmt.Errorf("%q cannot be traversed since its parent is of type %s", key, schema.Type)
+	}
+}
+
+// validateValue validates that the given value is of the type defined
+// by the given schema.  If the value is part of a patch document, keys
+// of patch directives (starting with '$') are ignored and null values
+// are accepted since they remove a field.
+func validateValue(value interface{}, schema *apiextv1.JSONSchemaProps, patch bool, fldPath *field.Path) field.ErrorList {
+	if schema == nil || value == nil {
+		return nil
+	}
+	if isIntOrString(schema) {
+		if _, ok := value.(string); ok || isInteger(value) {
+			return nil
+		}
+		return field.ErrorList{field.TypeInvalid(fldPath, value, "must be an integer or a string")}
+	}
+
+	allErrs := field.ErrorList{}
+	switch schema.Type {
+	case "string":
+		if _, ok := value.(string); !ok {
+			allErrs = append(allErrs, field.TypeInvalid(fldPath, value, "must be a string"))
+		}
+	case "integer":
+		if !isInteger(value) {
+			allErrs = append(allErrs, field.TypeInvalid(fldPath, value, "must be an integer"))
+		}
+	case "number":
+		switch value.(type) {
+		case int64, float64:
+		default:
+			allErrs = append(allErrs, field.TypeInvalid(fldPath, value, "must be a number"))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			allErrs = append(allErrs, field.TypeInvalid(fldPath, value, "must be a boolean"))
+		}
+	case "array":
+		elements, ok := value.([]interface{})
+		if !ok {
+			return append(allErrs, field.TypeInvalid(fldPath, value, "must be an array"))
+		}
+		if schema.Items == nil {
+			return allErrs
+		}
+		for i, element := range elements {
+			allErrs = append(allErrs, validateValue(element, schema.Items.Schema, patch, fldPath.Index(i))...)
+		}
+	case "object", "":
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			if schema.Type == "" {
+				return allErrs
+			}
+			return append(allErrs, field.TypeInvalid(fldPath, value, "must be an object"))
+		}
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if patch && strings.HasPrefix(key, "$") {
+				continue
+			}
+			fieldSchema, err := childSchema(schema, key)
+			if err != nil {
+				allErrs = append(allErrs, field.NotSupported(fldPath, key, knownFields(schema)))
+				continue
+			}
+			allErrs = append(allErrs, validateValue(fields[key], fieldSchema, patch, fldPath.Child(key))...)
+		}
+	}
+	return allErrs
+}
+
+func isIntOrString(schema *apiextv1.JSONSchemaProps) bool {
+	return schema.XIntOrString || len(schema.AnyOf) > 0
+}
+
+func isInteger(value interface{}) bool {
+	switch typedValue := value.(type) {
+	case int64:
+		return true
+	case float64:
+		return typedValue == math.Trunc(typedValue)
+	}
+	return false
+}
+
+func preservesUnknownFields(schema *apiextv1.JSONSchemaProps) bool {
+	if schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields {
+		return true
+	}
+	// An object without properties, e.g. the metadata of a custom
+	// resource, does not constrain its fields.
+	return len(schema.Properties) == 0 && schema.AdditionalProperties == nil
+}
+
+func knownFields(schema *apiextv1.JSONSchemaProps) []string {
+	fields := make([]string, 0, len(schema.Properties))
+	for key := range schema.Properties {
+		fields = append(fields, key)
+	}
+	sort.Strings(fields)
+	return fields
+}
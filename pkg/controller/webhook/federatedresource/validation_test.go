@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedresource
+
+import (
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+func TestValidateOverrides(t *testing.T) {
+	templateSchema := map[string]apiextv1.JSONSchemaProps{
+		"metadata": {
+			Type: "object",
+		},
+		"spec": {
+			Type: "object",
+			Properties: map[string]apiextv1.JSONSchemaProps{
+				"replicas": {Type: "integer"},
+				"paused":   {Type: "boolean"},
+				"containers": {
+					Type: "array",
+					Items: &apiextv1.JSONSchemaPropsOrArray{
+						Schema: &apiextv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextv1.JSONSchemaProps{
+								"name":  {Type: "string"},
+								"image": {Type: "string"},
+							},
+						},
+					},
+				},
+				"selector": {
+					Type: "object",
+					AdditionalProperties: &apiextv1.JSONSchemaPropsOrBool{
+						Allows: true,
+						Schema: &apiextv1.JSONSchemaProps{Type: "string"},
+					},
+				},
+				"port": {XIntOrString: true},
+				"config": {
+					Type:                   "object",
+					XPreserveUnknownFields: ptr.To(true),
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		clusterOverride map[string]interface{}
+		expectedErr     bool
+	}{
+		"valid value": {
+			clusterOverride: map[string]interface{}{"path": "/spec/replicas", "value": int64(3)},
+		},
+		"valid array element": {
+			clusterOverride: map[string]interface{}{"op": "add", "path": "/spec/containers/-", "value": map[string]interface{}{"name": "app"}},
+		},
+		"valid map entry": {
+			clusterOverride: map[string]interface{}{"path": "/spec/selector/app~1name", "value": "foo"},
+		},
+		"valid int or string": {
+			clusterOverride: map[string]interface{}{"path": "/spec/port", "value": "http"},
+		},
+		"valid unknown field": {
+			clusterOverride: map[string]interface{}{"path": "/spec/config/foo/bar", "value": true},
+		},
+		"valid metadata": {
+			clusterOverride: map[string]interface{}{"path": "/metadata/labels", "value": map[string]interface{}{"foo": "bar"}},
+		},
+		"remove without value": {
+			clusterOverride: map[string]interface{}{"op": "remove", "path": "/spec/paused"},
+		},
+		"unknown field": {
+			clusterOverride: map[string]interface{}{"path": "/spec/replica", "value": int64(3)},
+			expectedErr:     true,
+		},
+		"invalid array index": {
+			clusterOverride: map[string]interface{}{"path": "/spec/containers/first/image", "value": "foo"},
+			expectedErr:     true,
+		},
+		"traversing a primitive": {
+			clusterOverride: map[string]interface{}{"path": "/spec/replicas/foo", "value": "foo"},
+			expectedErr:     true,
+		},
+		"mistyped value": {
+			clusterOverride: map[string]interface{}{"path": "/spec/replicas", "value": "3"},
+			expectedErr:     true,
+		},
+		"mistyped nested value": {
+			clusterOverride: map[string]interface{}{"path": "/spec/containers/0", "value": map[string]interface{}{"image": int64(1)}},
+			expectedErr:     true,
+		},
+		"mistyped int or string": {
+			clusterOverride: map[string]interface{}{"path": "/spec/port", "value": true},
+			expectedErr:     true,
+		},
+		"invalid pointer": {
+			clusterOverride: map[string]interface{}{"path": "spec/replicas", "value": int64(3)},
+			expectedErr:     true,
+		},
+		"valid patch": {
+			clusterOverride: map[string]interface{}{
+				"type": utils.StrategicMergeOverrideType,
+				"patch": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"paused": nil,
+						"containers": []interface{}{
+							map[string]interface{}{"name": "app", "$patch": "delete"},
+						},
+						"$setElementOrder/containers": []interface{}{},
+					},
+				},
+			},
+		},
+		"patch with unknown field": {
+			clusterOverride: map[string]interface{}{
+				"type": utils.MergePatchOverrideType,
+				"patch": map[string]interface{}{
+					"spec": map[string]interface{}{"replica": int64(3)},
+				},
+			},
+			expectedErr: true,
+		},
+		"patch with mistyped value": {
+			clusterOverride: map[string]interface{}{
+				"type": utils.MergePatchOverrideType,
+				"patch": map[string]interface{}{
+					"spec": map[string]interface{}{"paused": "yes"},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					utils.SpecField: map[string]interface{}{
+						utils.OverridesField: []interface{}{
+							map[string]interface{}{
+								utils.ClusterNameField:      "cluster1",
+								utils.ClusterOverridesField: []interface{}{testCase.clusterOverride},
+							},
+						},
+					},
+				},
+			}
+			errs := ValidateOverrides(obj, templateSchema)
+			if testCase.expectedErr && len(errs) == 0 {
+				t.Fatalf("Expected an error, got none")
+			}
+			if !testCase.expectedErr && len(errs) != 0 {
+				t.Fatalf("An unexpected error occurred: %v", errs.ToAggregate())
+			}
+		})
+	}
+}
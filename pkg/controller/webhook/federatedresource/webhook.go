@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedresource
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+	"sigs.k8s.io/kubefed/pkg/controller/webhook"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/enable"
+)
+
+const (
+	ResourceName = "FederatedResource"
+
+	// schemaTTL is the duration for which the schema of a target type
+	// is cached before it is retrieved again.
+	schemaTTL = 5 * time.Minute
+)
+
+type cachedSchema struct {
+	templateSchema map[string]apiextv1.JSONSchemaProps
+	fetched        time.Time
+}
+
+// AdmissionHook validates the overrides of federated resources
+// against the schema of their target types.
+type AdmissionHook struct {
+	config           *rest.Config
+	client           genericclient.Client
+	kubeFedNamespace string
+
+	lock    sync.Mutex
+	schemas map[schema.GroupVersionResource]cachedSchema
+}
+
+var _ admission.Handler = &AdmissionHook{}
+
+// NewAdmissionHook returns an admission hook that looks up the
+// FederatedTypeConfigs of federated resources in the given KubeFed
+// namespace.
+func NewAdmissionHook(config *rest.Config, kubeFedNamespace string) (*AdmissionHook, error) {
+	client, err := genericclient.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return &AdmissionHook{
+		config:           config,
+		client:           client,
+		kubeFedNamespace: kubeFedNamespace,
+		schemas:          make(map[schema.GroupVersionResource]cachedSchema),
+	}, nil
+}
+
+func (a *AdmissionHook) Handle(ctx context.Context, admissionSpec admission.Request) admission.Response {
+	klog.V(4).Infof("Validating %q AdmissionRequest = %s", ResourceName, webhook.AdmissionRequestDebugString(admissionSpec))
+
+	// We want to let through:
+	// - Requests that are not for create, update
+	// - Requests for subresources like status
+	createOrUpdate := admissionSpec.Operation == admissionv1.Create || admissionSpec.Operation == admissionv1.Update
+	if !createOrUpdate || len(admissionSpec.SubResource) != 0 {
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: true,
+			},
+		}
+	}
+
+	admittingObject := &unstructured.Unstructured{}
+	if err := admittingObject.UnmarshalJSON(admissionSpec.Object.Raw); err != nil {
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+					Message: err.Error(),
+				},
+			},
+		}
+	}
+
+	if admissionSpec.Operation == admissionv1.Update {
+		oldObject := &unstructured.Unstructured{}
+		if err := oldObject.UnmarshalJSON(admissionSpec.OldObject.Raw); err != nil {
+			return admission.Response{
+				AdmissionResponse: admissionv1.AdmissionResponse{
+					Allowed: false,
+					Result: &metav1.Status{
+						Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+						Message: err.Error(),
+					},
+				},
+			}
+		}
+		// Overrides admitted before they could be validated, or
+		// before the schema of the target type changed, must not
+		// prevent updates that leave them unchanged, e.g. the removal
+		// of a finalizer or the rollback of the resource.  Updates to
+		// a resource being deleted are always allowed so that its
+		// deletion can complete.
+		if admittingObject.GetDeletionTimestamp() != nil || !overridesChanged(oldObject, admittingObject) {
+			return admission.Response{
+				AdmissionResponse: admissionv1.AdmissionResponse{
+					Allowed: true,
+				},
+			}
+		}
+	}
+
+	resource := schema.GroupVersionResource{
+		Group:    admissionSpec.Resource.Group,
+		Version:  admissionSpec.Resource.Version,
+		Resource: admissionSpec.Resource.Resource,
+	}
+	templateSchema, err := a.templateSchema(ctx, resource)
+	if err != nil {
+		// Overrides are still validated by the sync controller, so
+		// avoid blocking the request if the schema is not available.
+		klog.Warningf("Skipping validation of overrides for %s %s/%s: %v", resource, admissionSpec.Namespace, admissionSpec.Name, err)
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: true,
+			},
+		}
+	}
+
+	return webhook.Validate(func() field.ErrorList {
+		return ValidateOverrides(admittingObject, templateSchema)
+	})
+}
+
+// overridesChanged returns whether the overrides of the given
+// federated resources differ.
+func overridesChanged(oldObject, newObject *unstructured.Unstructured) bool {
+	oldOverrides, _, _ := unstructured.NestedFieldNoCopy(oldObject.Object, utils.SpecField, utils.OverridesField)
+	newOverrides, _, _ := unstructured.NestedFieldNoCopy(newObject.Object, utils.SpecField, utils.OverridesField)
+	return !equality.Semantic.DeepEqual(oldOverrides, newOverrides)
+}
+
+// templateSchema returns the schema of the target type of the given
+// federated type.
+func (a *AdmissionHook) templateSchema(ctx context.Context, resource schema.GroupVersionResource) (map[string]apiextv1.JSONSchemaProps, error) {
+	a.lock.Lock()
+	cached, ok := a.schemas[resource]
+	a.lock.Unlock()
+	if ok && time.Since(cached.fetched) < schemaTTL {
+		return cached.templateSchema, nil
+	}
+
+	typeConfigs := &v1beta1.FederatedTypeConfigList{}
+	if err := a.client.List(ctx, typeConfigs, a.kubeFedNamespace); err != nil {
+		return nil, errors.Wrap(err, "failed to list FederatedTypeConfigs")
+	}
+	for i := range typeConfigs.Items {
+		typeConfig := &typeConfigs.Items[i]
+		fedType := typeConfig.GetFederatedType()
+		if fedType.Group != resource.Group || fedType.Version != resource.Version || fedType.Name != resource.Resource {
+			continue
+		}
+		templateSchema, err := enable.TemplateSchema(a.config, typeConfig.GetTargetType())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve the schema of the target type of FederatedTypeConfig %q", typeConfig.Name)
+		}
+		a.lock.Lock()
+		a.schemas[resource] = cachedSchema{templateSchema: templateSchema, fetched: time.Now()}
+		a.lock.Unlock()
+		return templateSchema, nil
+	}
+	return nil, errors.Errorf("no FederatedTypeConfig found in namespace %q", a.kubeFedNamespace)
+}
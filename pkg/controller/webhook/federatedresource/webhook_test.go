@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federatedresource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/kubefed/pkg/controller/utils"
+)
+
+func TestHandle(t *testing.T) {
+	resource := schema.GroupVersionResource{
+		Group:    "types.kubefed.io",
+		Version:  "v1beta1",
+		Resource: "federateddeployments",
+	}
+	hook := &AdmissionHook{
+		schemas: map[schema.GroupVersionResource]cachedSchema{
+			resource: {
+				templateSchema: map[string]apiextv1.JSONSchemaProps{
+					"spec": {
+						Type: "object",
+						Properties: map[string]apiextv1.JSONSchemaProps{
+							"replicas": {Type: "integer"},
+						},
+					},
+				},
+				fetched: time.Now(),
+			},
+		},
+	}
+
+	newObject := func(path string, finalizers ...string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "types.kubefed.io/v1beta1",
+				"kind":       "FederatedDeployment",
+				"metadata": map[string]interface{}{
+					"name":      "foo",
+					"namespace": "bar",
+				},
+				utils.SpecField: map[string]interface{}{
+					utils.OverridesField: []interface{}{
+						map[string]interface{}{
+							utils.ClusterNameField: "cluster1",
+							utils.ClusterOverridesField: []interface{}{
+								map[string]interface{}{"path": path, "value": int64(3)},
+							},
+						},
+					},
+				},
+			},
+		}
+		obj.SetFinalizers(finalizers)
+		return obj
+	}
+	deletedObject := func(obj *unstructured.Unstructured) *unstructured.Unstructured {
+		obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+		return obj
+	}
+
+	testCases := map[string]struct {
+		operation       admissionv1.Operation
+		oldObject       *unstructured.Unstructured
+		newObject       *unstructured.Unstructured
+		expectedAllowed bool
+	}{
+		"create with valid overrides": {
+			operation:       admissionv1.Create,
+			newObject:       newObject("/spec/replicas"),
+			expectedAllowed: true,
+		},
+		"create with invalid overrides": {
+			operation: admissionv1.Create,
+			newObject: newObject("/spec/replica"),
+		},
+		"update to invalid overrides": {
+			operation: admissionv1.Update,
+			oldObject: newObject("/spec/replicas"),
+			newObject: newObject("/spec/replica"),
+		},
+		"update to valid overrides": {
+			operation:       admissionv1.Update,
+			oldObject:       newObject("/spec/replica"),
+			newObject:       newObject("/spec/replicas"),
+			expectedAllowed: true,
+		},
+		"finalizer-only update with invalid overrides": {
+			operation:       admissionv1.Update,
+			oldObject:       newObject("/spec/replica"),
+			newObject:       newObject("/spec/replica", "kubefed.io/sync-controller"),
+			expectedAllowed: true,
+		},
+		"deletion with invalid overrides": {
+			operation:       admissionv1.Update,
+			oldObject:       deletedObject(newObject("/spec/replicas", "kubefed.io/sync-controller")),
+			newObject:       deletedObject(newObject("/spec/replica")),
+			expectedAllowed: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			request := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: testCase.operation,
+					Resource: metav1.GroupVersionResource{
+						Group:    resource.Group,
+						Version:  resource.Version,
+						Resource: resource.Resource,
+					},
+					Namespace: "bar",
+					Name:      "foo",
+					DryRun:    ptr.To(false),
+				},
+			}
+			raw, err := testCase.newObject.MarshalJSON()
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			request.Object = runtime.RawExtension{Raw: raw}
+			if testCase.oldObject != nil {
+				raw, err = testCase.oldObject.MarshalJSON()
+				if err != nil {
+					t.Fatalf("An unexpected error occurred: %v", err)
+				}
+				request.OldObject = runtime.RawExtension{Raw: raw}
+			}
+
+			response := hook.Handle(context.TODO(), request)
+			if response.Allowed != testCase.expectedAllowed {
+				t.Fatalf("Expected allowed to be %v, got %v: %v", testCase.expectedAllowed, response.Allowed, response.Result)
+			}
+		})
+	}
+}
@@ -49,6 +49,18 @@ func newSchemaAccessor(config *rest.Config, apiResource metav1.APIResource) (sch
 	return newOpenAPISchemaAccessor(config, apiResource)
 }
 
+// TemplateSchema returns the schema of the properties of the given
+// target type.  The schema is sourced from the CRD of the type if it
+// is a custom resource and from the OpenAPI schema of the API server
+// otherwise.
+func TemplateSchema(config *rest.Config, apiResource metav1.APIResource) (map[string]apiextv1.JSONSchemaProps, error) {
+	accessor, err := newSchemaAccessor(config, apiResource)
+	if err != nil {
+		return nil, err
+	}
+	return accessor.templateSchema(), nil
+}
+
 type crdSchemaAccessor struct {
 	validation *apiextv1.CustomResourceValidation
 }
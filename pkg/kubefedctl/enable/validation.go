@@ -31,67 +31,187 @@ func federatedTypeValidationSchema(templateSchema map[string]v1.JSONSchemaProps)
 				Properties: map[string]v1.JSONSchemaProps{
 					// References to one or more clusters allow a
 					// scheduling mechanism to explicitly indicate
-					// placement. If one or more clusters is provided,
-					// the clusterSelector field will be ignored.
-					"clusters": {
+					// placement. If one or more clusters is provided
+					// and no operator is set, the clusterSelector
+					// field will be ignored.
+					"clusters": clusterReferencesSchema(),
+					// Clusters that will never be selected, regardless
+					// of the clusters and clusterSelector fields.
+					"excludeClusters": clusterReferencesSchema(),
+					// The operator combining clusters and
+					// clusterSelector.
+					"operator": {
+						Type:    "string",
+						Pattern: "^(Union|Intersection)?$",
+					},
+					// Regions and zones restrict placement to clusters
+					// whose discovered topology matches.
+					"regions": stringArraySchema(),
+					"zones":   stringArraySchema(),
+					// Spread constraints bound the number of selected
+					// clusters and spread them across topology domains.
+					"spreadConstraints": {
+						Type: "object",
+						Properties: map[string]v1.JSONSchemaProps{
+							"maxClusters": {
+								Type:    "integer",
+								Format:  "int64",
+								Minimum: ptr.To[float64](0),
+							},
+							"topologyKey": {
+								Type: "string",
+							},
+							"maxPerTopology": {
+								Type:    "integer",
+								Format:  "int64",
+								Minimum: ptr.To[float64](1),
+							},
+						},
+					},
+					// Failover names standby clusters used while too
+					// few selected clusters are ready.
+					"failover": {
+						Type: "object",
+						Properties: map[string]v1.JSONSchemaProps{
+							"minReadyClusters": {
+								Type:    "integer",
+								Format:  "int64",
+								Minimum: ptr.To[float64](1),
+							},
+							"clusters":        clusterReferencesSchema(),
+							"clusterSelector": labelSelectorSchema(),
+							"revertGracePeriodSeconds": {
+								Type:    "integer",
+								Format:  "int64",
+								Minimum: ptr.To[float64](0),
+							},
+						},
+						Required: []string{
+							"minReadyClusters",
+						},
+					},
+					// Tolerations allow placement in clusters with
+					// matching taints.
+					"tolerations": {
 						Type: "array",
 						Items: &v1.JSONSchemaPropsOrArray{
 							Schema: &v1.JSONSchemaProps{
 								Type: "object",
 								Properties: map[string]v1.JSONSchemaProps{
-									"name": {
+									"key": {
 										Type: "string",
 									},
+									"operator": {
+										Type:    "string",
+										Pattern: "^(Exists|Equal)?$",
+									},
+									"value": {
+										Type: "string",
+									},
+									"effect": {
+										Type:    "string",
+										Pattern: "^(NoSchedule|NoExecute)?$",
+									},
 								},
-								Required: []string{
-									"name",
+							},
+						},
+					},
+					"clusterSelector": labelSelectorSchema(),
+				},
+			},
+			// A reference to a PlacementPolicy whose placement is
+			// used instead of the placement field.
+			"placementRef": {
+				Type: "object",
+				Properties: map[string]v1.JSONSchemaProps{
+					"name": {
+						Type: "string",
+					},
+				},
+				Required: []string{
+					"name",
+				},
+			},
+			// The order in which changes are propagated to the
+			// selected clusters.
+			"rolloutStrategy": {
+				Type: "object",
+				Properties: map[string]v1.JSONSchemaProps{
+					"waves": {
+						Type: "array",
+						Items: &v1.JSONSchemaPropsOrArray{
+							Schema: &v1.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]v1.JSONSchemaProps{
+									"clusters":        clusterReferencesSchema(),
+									"clusterSelector": labelSelectorSchema(),
 								},
 							},
 						},
 					},
-					"clusterSelector": {
+					"maxUnavailableClusters": {
+						Type:    "integer",
+						Format:  "int64",
+						Minimum: ptr.To[float64](1),
+					},
+				},
+			},
+			// Federated resources that must be propagated to a
+			// cluster before the resource is created there.
+			"dependsOn": {
+				Type: "array",
+				Items: &v1.JSONSchemaPropsOrArray{
+					Schema: &v1.JSONSchemaProps{
 						Type: "object",
 						Properties: map[string]v1.JSONSchemaProps{
-							"matchExpressions": {
-								Type: "array",
-								Items: &v1.JSONSchemaPropsOrArray{
-									Schema: &v1.JSONSchemaProps{
-										Type: "object",
-										Properties: map[string]v1.JSONSchemaProps{
-											"key": {
-												Type: "string",
-											},
-											"operator": {
-												Type: "string",
-											},
-											"values": {
-												Type: "array",
-												Items: &v1.JSONSchemaPropsOrArray{
-													Schema: &v1.JSONSchemaProps{
-														Type: "string",
-													},
-												},
-											},
-										},
-										Required: []string{
-											"key",
-											"operator",
-										},
-									},
-								},
+							"apiVersion": {
+								Type: "string",
 							},
-							"matchLabels": {
-								Type: "object",
-								AdditionalProperties: &v1.JSONSchemaPropsOrBool{
-									Schema: &v1.JSONSchemaProps{
-										Type: "string",
-									},
-								},
+							"kind": {
+								Type: "string",
+							},
+							"name": {
+								Type: "string",
+							},
+							"namespace": {
+								Type: "string",
 							},
 						},
+						Required: []string{
+							"kind",
+							"name",
+						},
 					},
 				},
 			},
+			// The number of revisions of the template and overrides
+			// to keep for rollback.
+			"revisionHistoryLimit": {
+				Type:    "integer",
+				Format:  "int32",
+				Minimum: ptr.To[float64](1),
+			},
+			// When the resource is rolled back to its last healthy
+			// revision.
+			"rollbackPolicy": {
+				Type: "object",
+				Properties: map[string]v1.JSONSchemaProps{
+					"failureTimeoutSeconds": {
+						Type:    "integer",
+						Format:  "int64",
+						Minimum: ptr.To[float64](1),
+					},
+				},
+				Required: []string{
+					"failureTimeoutSeconds",
+				},
+			},
+			// Whether changes made to resources in member clusters
+			// are reverted or only reported in status.
+			"driftPolicy": {
+				Type:    "string",
+				Pattern: "^(Revert|Report)?$",
+			},
 			"overrides": {
 				Type: "array",
 				Items: &v1.JSONSchemaPropsOrArray{
@@ -101,6 +221,9 @@ func federatedTypeValidationSchema(templateSchema map[string]v1.JSONSchemaProps)
 							"clusterName": {
 								Type: "string",
 							},
+							// Selects the clusters the overrides
+							// apply to instead of clusterName.
+							"clusterSelector": labelSelectorSchema(),
 							"clusterOverrides": {
 								Type: "array",
 								Items: &v1.JSONSchemaPropsOrArray{
@@ -117,9 +240,23 @@ func federatedTypeValidationSchema(templateSchema map[string]v1.JSONSchemaProps)
 											"value": {
 												XPreserveUnknownFields: ptr.To(true),
 											},
-										},
-										Required: []string{
-											"path",
+											// Whether the strings of value
+											// or patch are templates to be
+											// expanded for each cluster.
+											"template": {
+												Type: "boolean",
+											},
+											// Patch documents are applied
+											// instead of op, path and value
+											// for the patch types.
+											"type": {
+												Type:    "string",
+												Pattern: "^(JSONPatch|StrategicMerge|MergePatch)?$",
+											},
+											"patch": {
+												Type:                   "object",
+												XPreserveUnknownFields: ptr.To(true),
+											},
 										},
 									},
 								},
@@ -152,6 +289,80 @@ func federatedTypeValidationSchema(templateSchema map[string]v1.JSONSchemaProps)
 	return schema
 }
 
+func labelSelectorSchema() v1.JSONSchemaProps {
+	return v1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]v1.JSONSchemaProps{
+			"matchExpressions": {
+				Type: "array",
+				Items: &v1.JSONSchemaPropsOrArray{
+					Schema: &v1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]v1.JSONSchemaProps{
+							"key": {
+								Type: "string",
+							},
+							"operator": {
+								Type: "string",
+							},
+							"values": {
+								Type: "array",
+								Items: &v1.JSONSchemaPropsOrArray{
+									Schema: &v1.JSONSchemaProps{
+										Type: "string",
+									},
+								},
+							},
+						},
+						Required: []string{
+							"key",
+							"operator",
+						},
+					},
+				},
+			},
+			"matchLabels": {
+				Type: "object",
+				AdditionalProperties: &v1.JSONSchemaPropsOrBool{
+					Schema: &v1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+}
+
+func clusterReferencesSchema() v1.JSONSchemaProps {
+	return v1.JSONSchemaProps{
+		Type: "array",
+		Items: &v1.JSONSchemaPropsOrArray{
+			Schema: &v1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]v1.JSONSchemaProps{
+					"name": {
+						Type: "string",
+					},
+				},
+				Required: []string{
+					"name",
+				},
+			},
+		},
+	}
+}
+
+func stringArraySchema() v1.JSONSchemaProps {
+	return v1.JSONSchemaProps{
+		Type: "array",
+		Items: &v1.JSONSchemaPropsOrArray{
+			Schema: &v1.JSONSchemaProps{
+				Type: "string",
+			},
+		},
+	}
+}
+
 func ValidationSchema(specProps v1.JSONSchemaProps) *v1.CustomResourceValidation {
 	return &v1.CustomResourceValidation{
 		OpenAPIV3Schema: &v1.JSONSchemaProps{
@@ -186,6 +397,9 @@ func ValidationSchema(specProps v1.JSONSchemaProps) *v1.CustomResourceValidation
 										"reason": {
 											Type: "string",
 										},
+										"message": {
+											Type: "string",
+										},
 										"lastUpdateTime": {
 											Format: "date-time",
 											Type:   "string",
@@ -218,6 +432,7 @@ func ValidationSchema(specProps v1.JSONSchemaProps) *v1.CustomResourceValidation
 											XPreserveUnknownFields: ptr.To(true),
 											Type:                   "object",
 										},
+										"driftedFields": stringArraySchema(),
 									},
 									Required: []string{
 										"name",
@@ -229,6 +444,20 @@ func ValidationSchema(specProps v1.JSONSchemaProps) *v1.CustomResourceValidation
 							Format: "int64",
 							Type:   "integer",
 						},
+						"failover": {
+							Type: "object",
+							Properties: map[string]v1.JSONSchemaProps{
+								"clusters": stringArraySchema(),
+								"activationTime": {
+									Format: "date-time",
+									Type:   "string",
+								},
+								"recoveryTime": {
+									Format: "date-time",
+									Type:   "string",
+								},
+							},
+						},
 					},
 				},
 			},
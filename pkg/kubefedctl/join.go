@@ -772,7 +772,8 @@ func createRoleAndBinding(clientset kubeclient.Interface, saName, namespace, clu
 
 // createHealthCheckClusterRoleAndBinding creates an RBAC cluster role and
 // binding that allows the service account identified by saName to
-// access the health check path of the cluster.
+// access the health check path of the cluster and the leases used to
+// determine the heartbeats of its nodes and critical components.
 func createHealthCheckClusterRoleAndBinding(clientset kubeclient.Interface, saName, namespace, clusterName string, dryRun, errorOnExisting bool) error {
 	if dryRun {
 		return nil
@@ -796,6 +797,13 @@ func createHealthCheckClusterRoleAndBinding(clientset kubeclient.Interface, saNa
 				APIGroups: []string{""},
 				Resources: []string{"nodes"},
 			},
+			// The heartbeats of nodes and critical components are
+			// determined from leases in any namespace.
+			{
+				Verbs:     []string{"get", "list", "watch"},
+				APIGroups: []string{"coordination.k8s.io"},
+				Resources: []string{"leases"},
+			},
 		},
 	}
 	existingRole, err := clientset.RbacV1().ClusterRoles().Get(context.Background(), role.Name, metav1.GetOptions{})
@@ -66,6 +66,10 @@ func NewKubeFedCtlCommand(out io.Writer) *cobra.Command {
 	rootCmd.AddCommand(NewCmdJoin(out, fedConfig))
 	rootCmd.AddCommand(NewCmdUnjoin(out, fedConfig))
 	rootCmd.AddCommand(orphaning.NewCmdOrphaning(out, fedConfig))
+	rootCmd.AddCommand(NewCmdRender(out, fedConfig))
+	rootCmd.AddCommand(NewCmdRollback(out, fedConfig))
+	rootCmd.AddCommand(NewCmdPause(out, fedConfig))
+	rootCmd.AddCommand(NewCmdResume(out, fedConfig))
 	rootCmd.AddCommand(NewCmdVersion(out))
 
 	return rootCmd
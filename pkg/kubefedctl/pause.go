@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefedctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	ctlutil "sigs.k8s.io/kubefed/pkg/controller/utils"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/enable"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/options"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/util"
+)
+
+var (
+	pauseLong = `
+		Pause stops the propagation of changes to a federated resource
+		to member clusters without deleting the resource. The
+		propagation status of the resource continues to be updated.
+		Pausing a FederatedNamespace pauses the propagation of all the
+		federated resources in the namespace. This is accomplished by
+		adding 'kubefed.io/paused: true' as an annotation to the
+		federated resource.
+
+		Current context is assumed to be a Kubernetes cluster hosting
+		the kubefed control plane. Please use the
+		--host-cluster-context flag otherwise.`
+
+	pauseExample = `
+		# Pause propagation of the FederatedDeployment named "my-app" in namespace "my-ns"
+		kubefedctl pause federateddeployments.types.kubefed.io my-app -n my-ns
+
+		# Pause propagation of all federated resources in namespace "my-ns"
+		kubefedctl pause federatednamespaces.types.kubefed.io my-ns -n my-ns`
+
+	resumeLong = `
+		Resume restarts the propagation of a federated resource that
+		was paused by removing the 'kubefed.io/paused' annotation.
+		Changes made while paused are propagated to member clusters.
+
+		Current context is assumed to be a Kubernetes cluster hosting
+		the kubefed control plane. Please use the
+		--host-cluster-context flag otherwise.`
+
+	resumeExample = `
+		# Resume propagation of the FederatedDeployment named "my-app" in namespace "my-ns"
+		kubefedctl resume federateddeployments.types.kubefed.io my-app -n my-ns`
+)
+
+type pauseResource struct {
+	options.GlobalSubcommandOptions
+	typeName          string
+	resourceName      string
+	resourceNamespace string
+	paused            bool
+}
+
+// Bind adds the pause specific arguments to the flagset passed in as an
+// argument.
+func (o *pauseResource) Bind(flags *pflag.FlagSet) error {
+	flags.StringVarP(&o.resourceNamespace, "namespace", "n", "", "The namespace of the federated resource. Defaults to the namespace of the current context.")
+	err := flags.MarkHidden("kubefed-namespace")
+	if err != nil {
+		return err
+	}
+	return flags.MarkHidden("dry-run")
+}
+
+// NewCmdPause defines the `pause` command that pauses propagation of a
+// federated resource.
+func NewCmdPause(cmdOut io.Writer, config util.FedConfig) *cobra.Command {
+	return newCmdPauseResource(cmdOut, config, true, &cobra.Command{
+		Use:     "pause FEDERATED-TYPE NAME",
+		Short:   "Pause propagation of a federated resource to member clusters",
+		Long:    pauseLong,
+		Example: pauseExample,
+	})
+}
+
+// NewCmdResume defines the `resume` command that resumes propagation
+// of a paused federated resource.
+func NewCmdResume(cmdOut io.Writer, config util.FedConfig) *cobra.Command {
+	return newCmdPauseResource(cmdOut, config, false, &cobra.Command{
+		Use:     "resume FEDERATED-TYPE NAME",
+		Short:   "Resume propagation of a paused federated resource to member clusters",
+		Long:    resumeLong,
+		Example: resumeExample,
+	})
+}
+
+func newCmdPauseResource(cmdOut io.Writer, config util.FedConfig, paused bool, cmd *cobra.Command) *cobra.Command {
+	opts := &pauseResource{paused: paused}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		err := opts.Complete(args, config)
+		if err != nil {
+			klog.Fatalf("Error: %v", err)
+		}
+
+		err = opts.Run(cmdOut, config)
+		if err != nil {
+			klog.Fatalf("Error: %v", err)
+		}
+	}
+
+	flags := cmd.Flags()
+	opts.GlobalSubcommandBind(flags)
+	err := opts.Bind(flags)
+	if err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+
+	return cmd
+}
+
+// Complete ensures that options are valid.
+func (o *pauseResource) Complete(args []string, config util.FedConfig) error {
+	if len(args) == 0 {
+		return errors.New("FEDERATED-TYPE is required")
+	}
+	o.typeName = args[0]
+
+	if len(args) == 1 {
+		return errors.New("NAME is required")
+	}
+	o.resourceName = args[1]
+
+	if len(o.resourceNamespace) == 0 {
+		var err error
+		o.resourceNamespace, err = util.GetNamespace(o.HostClusterContext, o.Kubeconfig, config)
+		return err
+	}
+	return nil
+}
+
+// Run adds or removes the paused annotation of the federated resource.
+func (o *pauseResource) Run(cmdOut io.Writer, config util.FedConfig) error {
+	hostConfig, err := config.HostConfig(o.HostClusterContext, o.Kubeconfig)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get host cluster config")
+	}
+
+	fedAPIResource, err := enable.LookupAPIResource(hostConfig, o.typeName, "")
+	if err != nil {
+		return errors.Wrapf(err, "Failed to find federated type %s", o.typeName)
+	}
+	fedClient, err := ctlutil.NewResourceClient(hostConfig, fedAPIResource)
+	if err != nil {
+		return errors.Wrapf(err, "Error creating client for %s", fedAPIResource.Kind)
+	}
+	namespace := o.resourceNamespace
+	if !fedAPIResource.Namespaced {
+		namespace = ""
+	}
+	qualifiedName := ctlutil.QualifiedName{Namespace: namespace, Name: o.resourceName}
+	fedObject, err := fedClient.Resources(namespace).Get(context.Background(), o.resourceName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "Failed to retrieve %s %q", fedAPIResource.Kind, qualifiedName)
+	}
+
+	action := "resumed"
+	if o.paused {
+		action = "paused"
+	}
+	if ctlutil.IsPaused(fedObject) != o.paused {
+		if o.paused {
+			ctlutil.Pause(fedObject)
+		} else {
+			ctlutil.Resume(fedObject)
+		}
+		_, err = fedClient.Resources(namespace).Update(context.Background(), fedObject, metav1.UpdateOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "Failed to update %s %q", fedAPIResource.Kind, qualifiedName)
+		}
+	}
+	_, err = fmt.Fprintf(cmdOut, "%s %q %s\n", fedAPIResource.Kind, qualifiedName, action)
+	return err
+}
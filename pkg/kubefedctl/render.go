@@ -0,0 +1,330 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefedctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
+	ctlutil "sigs.k8s.io/kubefed/pkg/controller/utils"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/enable"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/options"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/util"
+)
+
+var (
+	renderLong = `
+		Render outputs the object that the sync controller would
+		propagate to a member cluster for a federated resource, after
+		applying the template, the fields retained from the object in
+		the member cluster and the overrides for the cluster. The
+		member cluster is only read from and is never modified.
+		Placement is not evaluated, so the object is rendered even if
+		the cluster is not selected.
+
+		Current context is assumed to be a Kubernetes cluster hosting
+		the kubefed control plane. Please use the
+		--host-cluster-context flag otherwise.`
+
+	renderExample = `
+		# Render the FederatedDeployment named "my-app" in namespace "my-ns" for cluster1
+		kubefedctl render federateddeployments.types.kubefed.io my-app -n my-ns --cluster=cluster1
+
+		# Show the difference between the object in cluster1 and the rendered object
+		kubefedctl render federateddeployments.types.kubefed.io my-app -n my-ns --cluster=cluster1 --diff`
+)
+
+type renderResource struct {
+	options.GlobalSubcommandOptions
+	typeName          string
+	resourceName      string
+	resourceNamespace string
+	clusterName       string
+	output            string
+	diff              bool
+	live              bool
+}
+
+// Bind adds the render specific arguments to the flagset passed in as an
+// argument.
+func (o *renderResource) Bind(flags *pflag.FlagSet) error {
+	flags.StringVarP(&o.resourceNamespace, "namespace", "n", "", "The namespace of the federated resource. Defaults to the namespace of the current context.")
+	flags.StringVar(&o.clusterName, "cluster", "", "The name of the member cluster to render the object for.")
+	flags.StringVarP(&o.output, "output", "o", "yaml", "The format of the rendered object. Valid formats are ['yaml', 'json'].")
+	flags.BoolVar(&o.diff, "diff", false, "If true, output a diff between the object in the member cluster and the rendered object instead of the rendered object.")
+	flags.BoolVar(&o.live, "live", true, "If true, retrieve the object from the member cluster to retain its fields. If false, the object is rendered as it would be created.")
+	return flags.MarkHidden("dry-run")
+}
+
+// NewCmdRender defines the `render` command that outputs the object
+// propagated to a member cluster for a federated resource.
+func NewCmdRender(cmdOut io.Writer, config util.FedConfig) *cobra.Command {
+	opts := &renderResource{}
+
+	cmd := &cobra.Command{
+		Use:     "render FEDERATED-TYPE NAME --cluster=CLUSTER",
+		Short:   "Render the object propagated to a member cluster for a federated resource",
+		Long:    renderLong,
+		Example: renderExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := opts.Complete(args, config)
+			if err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+
+			err = opts.Run(cmdOut, config)
+			if err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	opts.GlobalSubcommandBind(flags)
+	err := opts.Bind(flags)
+	if err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+
+	return cmd
+}
+
+// Complete ensures that options are valid.
+func (o *renderResource) Complete(args []string, config util.FedConfig) error {
+	if len(args) == 0 {
+		return errors.New("FEDERATED-TYPE is required")
+	}
+	o.typeName = args[0]
+
+	if len(args) == 1 {
+		return errors.New("NAME is required")
+	}
+	o.resourceName = args[1]
+
+	if len(o.clusterName) == 0 {
+		return errors.New("--cluster is required")
+	}
+	if o.output != "yaml" && o.output != "json" {
+		return errors.Errorf("Invalid value for --output: %s", o.output)
+	}
+	if o.diff && !o.live {
+		return errors.New("Flag '--diff' cannot be used with '--live=false'")
+	}
+
+	if len(o.resourceNamespace) == 0 {
+		var err error
+		o.resourceNamespace, err = util.GetNamespace(o.HostClusterContext, o.Kubeconfig, config)
+		return err
+	}
+	return nil
+}
+
+// Run renders the federated resource for the member cluster.
+func (o *renderResource) Run(cmdOut io.Writer, config util.FedConfig) error {
+	hostConfig, err := config.HostConfig(o.HostClusterContext, o.Kubeconfig)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get host cluster config")
+	}
+	client, err := genericclient.New(hostConfig)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get kubefed clientset")
+	}
+
+	typeConfig, err := o.lookupTypeConfig(hostConfig, client)
+	if err != nil {
+		return err
+	}
+
+	fedAPIResource := typeConfig.GetFederatedType()
+	fedClient, err := ctlutil.NewResourceClient(hostConfig, &fedAPIResource)
+	if err != nil {
+		return errors.Wrapf(err, "Error creating client for %s", fedAPIResource.Kind)
+	}
+	namespace := o.resourceNamespace
+	if !fedAPIResource.Namespaced {
+		namespace = ""
+	}
+	fedObject, err := fedClient.Resources(namespace).Get(context.Background(), o.resourceName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "Failed to retrieve %s %q", fedAPIResource.Kind,
+			ctlutil.QualifiedName{Namespace: namespace, Name: o.resourceName})
+	}
+
+	clusterList := &fedv1b1.KubeFedClusterList{}
+	err = client.List(context.TODO(), clusterList, o.KubeFedNamespace)
+	if err != nil {
+		return errors.Wrap(err, "Failed to list KubeFedClusters")
+	}
+	var cluster *fedv1b1.KubeFedCluster
+	clusters := make([]*fedv1b1.KubeFedCluster, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		clusters = append(clusters, &clusterList.Items[i])
+		if clusterList.Items[i].Name == o.clusterName {
+			cluster = &clusterList.Items[i]
+		}
+	}
+	if cluster == nil {
+		return errors.Errorf("KubeFedCluster %q not found in namespace %q", o.clusterName, o.KubeFedNamespace)
+	}
+
+	var clusterObj *unstructured.Unstructured
+	if o.live {
+		clusterObj, err = o.getClusterObject(client, cluster, typeConfig, fedObject)
+		if err != nil {
+			return err
+		}
+	}
+
+	renderedObj, err := sync.RenderForCluster(typeConfig, fedObject, clusters, o.clusterName, clusterObj)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to render %s %q for cluster %q", fedAPIResource.Kind,
+			ctlutil.NewQualifiedName(fedObject), o.clusterName)
+	}
+
+	if o.diff {
+		diffObj := renderedObj
+		if clusterObj != nil && typeConfig.GetServerSideApplyEnabled() {
+			// Metadata left to other field managers by the apply is
+			// not removed from the object in the member cluster.
+			diffObj = dispatch.WithRetainedMetadata(renderedObj, clusterObj)
+		}
+		return writeRenderDiff(cmdOut, o.clusterName, clusterObj, diffObj)
+	}
+	if o.output == "json" {
+		data, err := json.MarshalIndent(renderedObj.Object, "", "    ")
+		if err != nil {
+			return errors.Wrap(err, "Error encoding unstructured object to json")
+		}
+		_, err = cmdOut.Write(append(data, '\n'))
+		return err
+	}
+	return util.WriteUnstructuredToYaml(renderedObj, cmdOut)
+}
+
+// lookupTypeConfig returns the FederatedTypeConfig of the federated
+// type given by name.
+func (o *renderResource) lookupTypeConfig(hostConfig *rest.Config, client genericclient.Client) (typeconfig.Interface, error) {
+	apiResource, err := enable.LookupAPIResource(hostConfig, o.typeName, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to find federated type %s", o.typeName)
+	}
+
+	typeConfigList := &fedv1b1.FederatedTypeConfigList{}
+	err = client.List(context.TODO(), typeConfigList, o.KubeFedNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list FederatedTypeConfigs")
+	}
+	for i := range typeConfigList.Items {
+		fedType := typeConfigList.Items[i].GetFederatedType()
+		if fedType.Group == apiResource.Group && fedType.Name == apiResource.Name {
+			return &typeConfigList.Items[i], nil
+		}
+	}
+	return nil, errors.Errorf("No FederatedTypeConfig for federated type %s found in namespace %q",
+		typeconfig.GroupQualifiedName(*apiResource), o.KubeFedNamespace)
+}
+
+// getClusterObject retrieves the target object of the federated
+// resource from the member cluster.  Nil is returned if the object
+// does not exist.
+func (o *renderResource) getClusterObject(client genericclient.Client, cluster *fedv1b1.KubeFedCluster,
+	typeConfig typeconfig.Interface, fedObject *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	clusterConfig, err := ctlutil.BuildClusterConfig(cluster, client, o.KubeFedNamespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get config for cluster %q", cluster.Name)
+	}
+	targetAPIResource := typeConfig.GetTargetType()
+	targetClient, err := ctlutil.NewResourceClient(clusterConfig, &targetAPIResource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error creating client for %s", targetAPIResource.Kind)
+	}
+
+	namespace := ""
+	if targetAPIResource.Namespaced {
+		namespace = ctlutil.NamespaceForCluster(cluster.Name, fedObject.GetNamespace())
+	}
+	clusterObj, err := targetClient.Resources(namespace).Get(context.Background(), fedObject.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to retrieve %s %q from cluster %q", targetAPIResource.Kind,
+			ctlutil.QualifiedName{Namespace: namespace, Name: fedObject.GetName()}, cluster.Name)
+	}
+	return clusterObj, nil
+}
+
+// writeRenderDiff writes a unified diff between the yaml of the
+// object in the member cluster and the rendered object.  Fields that
+// are set by the API server are not compared.
+func writeRenderDiff(w io.Writer, clusterName string, clusterObj, renderedObj *unstructured.Unstructured) error {
+	clusterYAML := ""
+	if clusterObj != nil {
+		var err error
+		clusterYAML, err = diffableYAML(clusterObj)
+		if err != nil {
+			return err
+		}
+	}
+	renderedYAML, err := diffableYAML(renderedObj)
+	if err != nil {
+		return err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(clusterYAML),
+		B:        difflib.SplitLines(renderedYAML),
+		FromFile: "live/" + clusterName,
+		ToFile:   "rendered/" + clusterName,
+		Context:  3,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to compute diff")
+	}
+	_, err = io.WriteString(w, diff)
+	return err
+}
+
+func diffableYAML(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	buf := &bytes.Buffer{}
+	if err := util.WriteUnstructuredToYaml(obj, buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The CodeFuture Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefedctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/history"
+	ctlutil "sigs.k8s.io/kubefed/pkg/controller/utils"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/enable"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/options"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/util"
+)
+
+var (
+	rollbackLong = `
+		Rollback restores the template and overrides of a federated
+		resource from a revision recorded by the sync controller. By
+		default the most recent revision that differs from the current
+		template and overrides is restored. Revisions are only recorded
+		for federated resources that set spec.revisionHistoryLimit or
+		spec.rollbackPolicy.
+
+		Current context is assumed to be a Kubernetes cluster hosting
+		the kubefed control plane. Please use the
+		--host-cluster-context flag otherwise.`
+
+	rollbackExample = `
+		# Roll back the FederatedDeployment named "my-app" in namespace "my-ns" to its previous revision
+		kubefedctl rollback federateddeployments.types.kubefed.io my-app -n my-ns
+
+		# Roll back the FederatedDeployment named "my-app" in namespace "my-ns" to revision 3
+		kubefedctl rollback federateddeployments.types.kubefed.io my-app -n my-ns --to-revision=3`
+)
+
+type rollbackResource struct {
+	options.GlobalSubcommandOptions
+	typeName          string
+	resourceName      string
+	resourceNamespace string
+	toRevision        int64
+}
+
+// Bind adds the rollback specific arguments to the flagset passed in as an
+// argument.
+func (o *rollbackResource) Bind(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.resourceNamespace, "namespace", "n", "", "The namespace of the federated resource. Defaults to the namespace of the current context.")
+	flags.Int64Var(&o.toRevision, "to-revision", 0, "The revision to roll back to. Defaults to the most recent revision that differs from the current template and overrides.")
+}
+
+// NewCmdRollback defines the `rollback` command that restores a
+// previous revision of a federated resource.
+func NewCmdRollback(cmdOut io.Writer, config util.FedConfig) *cobra.Command {
+	opts := &rollbackResource{}
+
+	cmd := &cobra.Command{
+		Use:     "rollback FEDERATED-TYPE NAME",
+		Short:   "Roll back a federated resource to a previous revision",
+		Long:    rollbackLong,
+		Example: rollbackExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := opts.Complete(args, config)
+			if err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+
+			err = opts.Run(cmdOut, config)
+			if err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	opts.GlobalSubcommandBind(flags)
+	opts.Bind(flags)
+
+	return cmd
+}
+
+// Complete ensures that options are valid.
+func (o *rollbackResource) Complete(args []string, config util.FedConfig) error {
+	if len(args) == 0 {
+		return errors.New("FEDERATED-TYPE is required")
+	}
+	o.typeName = args[0]
+
+	if len(args) == 1 {
+		return errors.New("NAME is required")
+	}
+	o.resourceName = args[1]
+
+	if o.toRevision < 0 {
+		return errors.Errorf("Invalid value for --to-revision: %d", o.toRevision)
+	}
+
+	if len(o.resourceNamespace) == 0 {
+		var err error
+		o.resourceNamespace, err = util.GetNamespace(o.HostClusterContext, o.Kubeconfig, config)
+		return err
+	}
+	return nil
+}
+
+// Run restores the revision of the federated resource.
+func (o *rollbackResource) Run(cmdOut io.Writer, config util.FedConfig) error {
+	hostConfig, err := config.HostConfig(o.HostClusterContext, o.Kubeconfig)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get host cluster config")
+	}
+	client, err := genericclient.New(hostConfig)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get kubefed clientset")
+	}
+
+	fedAPIResource, err := enable.LookupAPIResource(hostConfig, o.typeName, "")
+	if err != nil {
+		return errors.Wrapf(err, "Failed to find federated type %s", o.typeName)
+	}
+	fedClient, err := ctlutil.NewResourceClient(hostConfig, fedAPIResource)
+	if err != nil {
+		return errors.Wrapf(err, "Error creating client for %s", fedAPIResource.Kind)
+	}
+	namespace := o.resourceNamespace
+	if !fedAPIResource.Namespaced {
+		namespace = ""
+	}
+	qualifiedName := ctlutil.QualifiedName{Namespace: namespace, Name: o.resourceName}
+	fedObject, err := fedClient.Resources(namespace).Get(context.Background(), o.resourceName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "Failed to retrieve %s %q", fedAPIResource.Kind, qualifiedName)
+	}
+
+	revisions, err := history.List(context.TODO(), client, fedObject, history.Namespace(fedObject, o.KubeFedNamespace))
+	if err != nil {
+		return errors.Wrapf(err, "Failed to list revisions of %s %q", fedAPIResource.Kind, qualifiedName)
+	}
+	revision, err := o.selectRevision(fedObject, revisions)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to find a revision of %s %q to roll back to", fedAPIResource.Kind, qualifiedName)
+	}
+
+	err = history.Restore(fedObject, revision)
+	if err != nil {
+		return err
+	}
+	_, err = fedClient.Resources(namespace).Update(context.Background(), fedObject, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "Failed to update %s %q", fedAPIResource.Kind, qualifiedName)
+	}
+	_, err = fmt.Fprintf(cmdOut, "%s %q rolled back to revision %d\n", fedAPIResource.Kind, qualifiedName, revision.Revision)
+	return err
+}
+
+// selectRevision returns the revision given by --to-revision, or the
+// most recent revision that differs from the current template and
+// overrides of the federated resource.
+func (o *rollbackResource) selectRevision(fedObject *unstructured.Unstructured, revisions []*appsv1.ControllerRevision) (*appsv1.ControllerRevision, error) {
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+		if o.toRevision != 0 && revision.Revision != o.toRevision {
+			continue
+		}
+		matches, err := history.Matches(fedObject, revision)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			return revision, nil
+		}
+		if o.toRevision != 0 {
+			return nil, errors.Errorf("the current template and overrides are those of revision %d", o.toRevision)
+		}
+	}
+	if o.toRevision != 0 {
+		return nil, errors.Errorf("revision %d not found", o.toRevision)
+	}
+	return nil, errors.New("no previous revision found")
+}
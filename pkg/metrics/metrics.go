@@ -87,6 +87,35 @@ var (
 		}, []string{"action"},
 	)
 
+	clusterPropagationThrottledTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cluster_propagation_throttled_total",
+			Help: "Number of operations delayed by the propagation rate limit of a cluster.",
+		}, []string{"cluster"},
+	)
+
+	clusterPropagationThrottleDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cluster_propagation_throttle_duration_seconds",
+			Help:    "Time operations were delayed by the propagation rate limit of a cluster.",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1.0, 2.5, 5.0, 7.5, 10.0, 12.5, 15.0, 17.5, 20.0, 22.5, 25.0, 27.5, 30.0, 50.0, 75.0, 100.0, 1000.0},
+		}, []string{"cluster"},
+	)
+
+	clusterPropagationInflight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_propagation_inflight_operations",
+			Help: "Number of operations in flight against a cluster.",
+		}, []string{"cluster"},
+	)
+
+	workQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "controller_runtime_queue_depth",
+			Help: "Number of resources waiting to be reconciled per controller and priority.",
+		}, []string{"controller", "priority"},
+	)
+
 	controllerRuntimeReconcileDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "controller_runtime_reconcile_duration_seconds",
@@ -152,6 +181,10 @@ func RegisterAll() {
 		joinedClusterDuration,
 		unjoinedClusterDuration,
 		dispatchOperationDuration,
+		clusterPropagationThrottledTotal,
+		clusterPropagationThrottleDuration,
+		clusterPropagationInflight,
+		workQueueDepth,
 		controllerRuntimeReconcileDuration,
 		controllerRuntimeReconcileDurationSummary,
 	)
@@ -191,6 +224,24 @@ func DispatchOperationDurationFromStart(action string, start time.Time) {
 	dispatchOperationDuration.WithLabelValues(action).Observe(duration.Seconds())
 }
 
+// ClusterPropagationThrottledFromStart records an operation against a
+// cluster that was delayed by its propagation rate limit
+func ClusterPropagationThrottledFromStart(cluster string, start time.Time) {
+	duration := time.Since(start)
+	clusterPropagationThrottledTotal.WithLabelValues(cluster).Inc()
+	clusterPropagationThrottleDuration.WithLabelValues(cluster).Observe(duration.Seconds())
+}
+
+// ClusterPropagationInflightInc increases by one the number of operations in flight against a cluster
+func ClusterPropagationInflightInc(cluster string) {
+	clusterPropagationInflight.WithLabelValues(cluster).Inc()
+}
+
+// ClusterPropagationInflightDec decreases by one the number of operations in flight against a cluster
+func ClusterPropagationInflightDec(cluster string) {
+	clusterPropagationInflight.WithLabelValues(cluster).Dec()
+}
+
 // ClusterHealthStatusDurationFromStart records the duration of the cluster health status operation
 func ClusterHealthStatusDurationFromStart(start time.Time) {
 	duration := time.Since(start)
@@ -221,6 +272,11 @@ func ReconcileFederatedResourcesDurationFromStart(start time.Time) {
 	reconcileFederatedResourcesDuration.Observe(duration.Seconds())
 }
 
+// UpdateWorkQueueDepth records the number of resources waiting to be reconciled by a controller with a priority
+func UpdateWorkQueueDepth(controller, priority string, depth int) {
+	workQueueDepth.WithLabelValues(controller, priority).Set(float64(depth))
+}
+
 // UpdateControllerReconcileDurationFromStart records the duration of the reconcile loop
 // of a controller
 func UpdateControllerReconcileDurationFromStart(controller string, start time.Time) {
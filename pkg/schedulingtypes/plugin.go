@@ -69,6 +69,7 @@ func NewPlugin(controllerConfig *utils.ControllerConfig, eventHandlers Scheduler
 		controllerConfig,
 		client,
 		&targetAPIResource,
+		utils.CacheTransformForType(typeConfig),
 		eventHandlers.ClusterEventHandler,
 		eventHandlers.ClusterLifecycleHandlers,
 	)
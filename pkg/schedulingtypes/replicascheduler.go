@@ -82,6 +82,7 @@ func NewReplicaScheduler(controllerConfig *ctlutil.ControllerConfig, eventHandle
 		controllerConfig,
 		client,
 		PodResource,
+		nil,
 		func(runtimeclient.Object) {},
 		eventHandlers.ClusterLifecycleHandlers,
 	)